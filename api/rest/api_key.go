@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type CreateAPIKeyReq struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required"`
+	// unix seconds, 0 means never expires
+	ExpireTime int64 `json:"expire_time"`
+}
+
+type APIKeyRes struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Prefix       string   `json:"prefix"`
+	Scopes       []string `json:"scopes"`
+	CreatorID    int64    `json:"creator_id"`
+	CreateTime   int64    `json:"create_time"`
+	ExpireTime   int64    `json:"expire_time"`
+	LastUsedTime int64    `json:"last_used_time"`
+}
+
+type CreateAPIKeyRes struct {
+	APIKeyRes
+	// the plaintext key is only returned once
+	Key string `json:"key"`
+}
+
+func (s *Server) newAPIKeyRes(apiKey *model.APIKey) APIKeyRes {
+	res := APIKeyRes{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     s.APIKeyService.Scopes(apiKey),
+		CreatorID:  apiKey.CreatorID,
+		CreateTime: apiKey.CreateTime.Unix(),
+	}
+	if !apiKey.ExpireTime.IsZero() {
+		res.ExpireTime = apiKey.ExpireTime.Unix()
+	}
+	if !apiKey.LastUsedTime.IsZero() {
+		res.LastUsedTime = apiKey.LastUsedTime.Unix()
+	}
+	return res
+}
+
+func (s *Server) initAPIKeyRouter(g *gin.RouterGroup, readOpt apiConfigOption, writeOpt apiConfigOption) {
+	g.GET("", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		apiKeys, err := s.APIKeyService.ListAPIKeys(ctx.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		list := make([]APIKeyRes, 0, len(apiKeys))
+		for _, apiKey := range apiKeys {
+			list = append(list, s.newAPIKeyRes(apiKey))
+		}
+		return list, nil
+	}, readOpt))
+
+	g.POST("", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req CreateAPIKeyReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		var expireTime time.Time
+		if req.ExpireTime != 0 {
+			expireTime = time.Unix(req.ExpireTime, 0)
+		}
+		// an api key creating keys could grant the scopes it was given to keys nobody owns
+		if strings.HasPrefix(ctx.Caller, apiKeyCallerPrefix) {
+			return nil, cerrcode.ErrPermissionDenied.Wrap("api keys can not create api keys")
+		}
+		// 0 when created by the cli
+		creatorID, _ := strconv.ParseInt(ctx.Caller, 10, 64)
+		apiKey, key, err := s.APIKeyService.CreateAPIKey(ctx.Ctx, req.Name, req.Scopes, expireTime, creatorID)
+		if err != nil {
+			return nil, err
+		}
+		return CreateAPIKeyRes{
+			APIKeyRes: s.newAPIKeyRes(apiKey),
+			Key:       key,
+		}, nil
+	}, writeOpt))
+
+	g.DELETE("/:id", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap("invalid api key id")
+		}
+		return nil, s.APIKeyService.RevokeAPIKey(ctx.Ctx, id)
+	}, writeOpt))
+}
@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/core/service"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type ListAuditLogsReq struct {
+	Actor  string `form:"actor"`
+	Action string `form:"action"`
+	Target string `form:"target"`
+	// unix seconds, [time_from, time_to)
+	TimeFrom int64 `form:"time_from"`
+	TimeTo   int64 `form:"time_to"`
+	// next_cursor of the previous page
+	Cursor int64 `form:"cursor"`
+	Limit  int   `form:"limit"`
+}
+
+type AuditLogRes struct {
+	ID     int64  `json:"id"`
+	Actor  string `json:"actor"`
+	Action string `json:"action"`
+	Target string `json:"target"`
+	// json: {"before":{...},"after":{...}}
+	Diff       string `json:"diff"`
+	ClientIP   string `json:"client_ip"`
+	RequestID  int64  `json:"request_id"`
+	CreateTime int64  `json:"create_time"`
+}
+
+type ListAuditLogsRes struct {
+	AuditLogs []AuditLogRes `json:"audit_logs"`
+	// 0 on the last page
+	NextCursor int64 `json:"next_cursor"`
+}
+
+func newAuditLogRes(auditLog *model.AuditLog) AuditLogRes {
+	return AuditLogRes{
+		ID:         auditLog.ID,
+		Actor:      auditLog.Actor,
+		Action:     auditLog.Action,
+		Target:     auditLog.Target,
+		Diff:       auditLog.Diff,
+		ClientIP:   auditLog.ClientIP,
+		RequestID:  auditLog.RequestID,
+		CreateTime: auditLog.CreateTime.Unix(),
+	}
+}
+
+func (s *Server) initAuditRouter(g *gin.RouterGroup, readOpt apiConfigOption) {
+	g.GET("", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req ListAuditLogsReq
+		if err := c.ShouldBindQuery(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		filter := service.AuditFilter{
+			Actor:  req.Actor,
+			Action: req.Action,
+			Target: req.Target,
+		}
+		if req.TimeFrom != 0 {
+			filter.TimeFrom = time.Unix(req.TimeFrom, 0)
+		}
+		if req.TimeTo != 0 {
+			filter.TimeTo = time.Unix(req.TimeTo, 0)
+		}
+		auditLogs, nextCursor, err := s.AuditService.ListAuditLogs(ctx.Ctx, filter, req.Cursor, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+		list := make([]AuditLogRes, 0, len(auditLogs))
+		for _, auditLog := range auditLogs {
+			list = append(list, newAuditLogRes(auditLog))
+		}
+		return ListAuditLogsRes{AuditLogs: list, NextCursor: nextCursor}, nil
+	}, readOpt))
+}
@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/zunkk/go-project-startup/internal/core/service"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type RegisterReq struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Nickname string `json:"nickname"`
+}
+
+type LoginReq struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// AuthTokenRes only has user_id and mfa_token if the second factor is required, the login is finished by /auth/mfa/verify
+type AuthTokenRes struct {
+	UserID            int64  `json:"user_id"`
+	Token             string `json:"token"`
+	ExpireTime        int64  `json:"expire_time"`
+	RefreshToken      string `json:"refresh_token"`
+	RefreshExpireTime int64  `json:"refresh_expire_time"`
+	MFAToken          string `json:"mfa_token,omitempty"`
+	MFAExpireTime     int64  `json:"mfa_expire_time,omitempty"`
+}
+
+type VerifyMFAReq struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	// totp code or recovery code
+	Code string `json:"code" binding:"required"`
+}
+
+// UnlockLoginReq unlocks either the user or the client ip
+type UnlockLoginReq struct {
+	UserID int64  `json:"user_id"`
+	IP     string `json:"ip"`
+}
+
+type JWTKeyRes struct {
+	KID        string `json:"kid"`
+	Algorithm  string `json:"alg"`
+	CreateTime int64  `json:"create_time"`
+}
+
+func newAuthTokenRes(t *service.AuthToken) AuthTokenRes {
+	if t.MFAToken != "" {
+		return AuthTokenRes{
+			UserID:        t.UserID,
+			MFAToken:      t.MFAToken,
+			MFAExpireTime: t.MFAExpireTime.Unix(),
+		}
+	}
+	return AuthTokenRes{
+		UserID:            t.UserID,
+		Token:             t.Token,
+		ExpireTime:        t.ExpireTime.Unix(),
+		RefreshToken:      t.RefreshToken,
+		RefreshExpireTime: t.RefreshExpireTime.Unix(),
+	}
+}
+
+func (s *Server) initAuthRouter(g *gin.RouterGroup) {
+	g.POST("/register", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req RegisterReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		authToken, err := s.AuthService.RegisterByUsername(ctx.Ctx, req.Username, req.Password, req.Nickname)
+		if err != nil {
+			return nil, err
+		}
+		return newAuthTokenRes(authToken), nil
+	}))
+
+	g.POST("/login", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req LoginReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		authToken, err := s.AuthService.LoginByUsername(ctx.Ctx, req.Username, req.Password, c.ClientIP())
+		if err != nil {
+			return nil, err
+		}
+		return newAuthTokenRes(authToken), nil
+	}))
+
+	g.POST("/mfa/verify", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req VerifyMFAReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		authToken, err := s.AuthService.VerifyMFA(ctx.Ctx, req.MFAToken, req.Code, c.ClientIP())
+		if err != nil {
+			return nil, err
+		}
+		return newAuthTokenRes(authToken), nil
+	}))
+
+	g.POST("/refresh", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req RefreshTokenReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		authToken, err := s.TokenService.Refresh(ctx.Ctx, req.RefreshToken)
+		if err != nil {
+			return nil, err
+		}
+		return newAuthTokenRes(authToken), nil
+	}))
+
+	g.POST("/logout", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req RefreshTokenReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.TokenService.Logout(ctx.Ctx, userID, req.RefreshToken)
+	}, apiNeedAuth()))
+
+	g.POST("/logout-all", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.TokenService.LogoutAll(ctx.Ctx, userID)
+	}, apiNeedAuth()))
+
+	g.POST("/keys/rotate", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		key, err := s.TokenService.RotateKey()
+		if err != nil {
+			return nil, err
+		}
+		return JWTKeyRes{
+			KID:        key.KID,
+			Algorithm:  key.Algorithm,
+			CreateTime: key.CreateTime.Unix(),
+		}, nil
+	}, apiNeedFromCli()))
+}
+
+func (s *Server) initLoginLockRouter(g *gin.RouterGroup, opt apiConfigOption) {
+	g.POST("/unlock", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req UnlockLoginReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		switch {
+		case req.UserID != 0 && req.IP == "":
+			return nil, s.AuthService.UnlockUser(ctx.Ctx, req.UserID)
+		case req.UserID == 0 && req.IP != "":
+			return nil, s.AuthService.UnlockIP(ctx.Ctx, req.IP)
+		default:
+			return nil, cerrcode.ErrRequestParameter.Wrap("one of user_id and ip is required")
+		}
+	}, opt))
+}
@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type SendEmailCodeReq struct {
+	Email string `json:"email" binding:"required"`
+	// register, login or link
+	Purpose string `json:"purpose" binding:"required"`
+}
+
+type EmailRegisterReq struct {
+	Email    string `json:"email" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Nickname string `json:"nickname"`
+}
+
+// EmailLoginReq logs in by either the password or the login code
+type EmailLoginReq struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type EmailPasswordResetReq struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type EmailPasswordResetConfirmReq struct {
+	Email    string `json:"email" binding:"required"`
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+func (s *Server) initEmailAuthRouter(g *gin.RouterGroup) {
+	g.POST("/code", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req SendEmailCodeReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		return nil, s.AuthService.SendEmailCode(ctx.Ctx, req.Email, req.Purpose)
+	}))
+
+	g.POST("/register", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req EmailRegisterReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		authToken, err := s.AuthService.RegisterByEmail(ctx.Ctx, req.Email, req.Code, req.Password, req.Nickname)
+		if err != nil {
+			return nil, err
+		}
+		return newAuthTokenRes(authToken), nil
+	}))
+
+	g.POST("/login", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req EmailLoginReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		if (req.Password == "") == (req.Code == "") {
+			return nil, cerrcode.ErrRequestParameter.Wrap("one of password and code is required")
+		}
+		if req.Code != "" {
+			authToken, err := s.AuthService.LoginByEmailCode(ctx.Ctx, req.Email, req.Code, c.ClientIP())
+			if err != nil {
+				return nil, err
+			}
+			return newAuthTokenRes(authToken), nil
+		}
+		authToken, err := s.AuthService.LoginByEmail(ctx.Ctx, req.Email, req.Password, c.ClientIP())
+		if err != nil {
+			return nil, err
+		}
+		return newAuthTokenRes(authToken), nil
+	}))
+
+	g.POST("/password-reset", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req EmailPasswordResetReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		return nil, s.AuthService.SendPasswordResetEmail(ctx.Ctx, req.Email)
+	}))
+
+	g.POST("/password-reset/confirm", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req EmailPasswordResetConfirmReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		return nil, s.AuthService.ResetPasswordByEmail(ctx.Ctx, req.Email, req.Token, req.Password)
+	}))
+}
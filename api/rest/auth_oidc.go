@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type OIDCStartRes struct {
+	// the provider login page the user is redirected to
+	AuthURL string `json:"auth_url"`
+}
+
+// OIDCCallbackReq is posted by the frontend with the state and the code it received from the provider redirect
+type OIDCCallbackReq struct {
+	State string `json:"state" binding:"required"`
+	Code  string `json:"code" binding:"required"`
+}
+
+func (s *Server) initOIDCAuthRouter(g *gin.RouterGroup) {
+	g.GET("/providers", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		return s.AuthService.ListAuthProviders(), nil
+	}))
+
+	g.POST("/:provider/start", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		authURL, err := s.AuthService.StartOIDC(ctx.Ctx, c.Param("provider"), 0)
+		if err != nil {
+			return nil, err
+		}
+		return OIDCStartRes{AuthURL: authURL}, nil
+	}))
+
+	g.POST("/:provider/callback", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req OIDCCallbackReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		authToken, err := s.AuthService.LoginByOIDC(ctx.Ctx, c.Param("provider"), req.State, req.Code)
+		if err != nil {
+			return nil, err
+		}
+		return newAuthTokenRes(authToken), nil
+	}))
+}
@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type TelegramWebAppLoginReq struct {
+	InitData string `json:"init_data" binding:"required"`
+}
+
+func (s *Server) initTelegramAuthRouter(g *gin.RouterGroup) {
+	// the body is the user object passed to the Login Widget callback as is
+	g.POST("/widget", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req map[string]any
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		fields, err := telegramWidgetFields(req)
+		if err != nil {
+			return nil, err
+		}
+		authToken, err := s.AuthService.LoginByTelegramWidget(ctx.Ctx, fields)
+		if err != nil {
+			return nil, err
+		}
+		return newAuthTokenRes(authToken), nil
+	}))
+
+	g.POST("/webapp", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req TelegramWebAppLoginReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		authToken, err := s.AuthService.LoginByTelegramWebApp(ctx.Ctx, req.InitData)
+		if err != nil {
+			return nil, err
+		}
+		return newAuthTokenRes(authToken), nil
+	}))
+}
+
+// telegramWidgetFields converts the Login Widget user object to the string fields which are signed
+func telegramWidgetFields(data map[string]any) (map[string]string, error) {
+	if len(data) == 0 {
+		return nil, cerrcode.ErrRequestParameter.Wrap("telegram widget data is empty")
+	}
+	fields := make(map[string]string, len(data))
+	for k, v := range data {
+		switch v := v.(type) {
+		case string:
+			fields[k] = v
+		case float64:
+			fields[k] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, cerrcode.ErrRequestParameter.Wrap(fmt.Sprintf("unsupported field %s", k))
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type DBStatusRes struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	// unix seconds of the latest health check, 0 before the first one
+	LastCheckTime int64  `json:"last_check_time"`
+	LastError     string `json:"last_error"`
+
+	// the connection pool statistics
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	// milliseconds
+	WaitDuration      int64 `json:"wait_duration"`
+	MaxIdleClosed     int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64 `json:"max_lifetime_closed"`
+
+	Replicas []DBStatusRes `json:"replicas,omitempty"`
+}
+
+type DBQueryMetricsRes struct {
+	Queries []DBQueryStatsRes `json:"queries"`
+}
+
+type DBQueryStatsRes struct {
+	Name       string `json:"name"`
+	Count      int64  `json:"count"`
+	ErrorCount int64  `json:"error_count"`
+	// milliseconds
+	TotalDuration float64 `json:"total_duration"`
+	MaxDuration   float64 `json:"max_duration"`
+	// the cumulative counts of the queries not slower than le milliseconds
+	Buckets []DBQueryLatencyBucketRes `json:"buckets"`
+}
+
+type DBQueryLatencyBucketRes struct {
+	Le    float64 `json:"le"`
+	Count int64   `json:"count"`
+}
+
+func newDBQueryStatsRes(stats dao.QueryStats) DBQueryStatsRes {
+	res := DBQueryStatsRes{
+		Name:          stats.Name,
+		Count:         stats.Count,
+		ErrorCount:    stats.ErrorCount,
+		TotalDuration: durationMilliseconds(stats.TotalDuration),
+		MaxDuration:   durationMilliseconds(stats.MaxDuration),
+	}
+	for _, bucket := range stats.Buckets {
+		res.Buckets = append(res.Buckets, DBQueryLatencyBucketRes{
+			Le:    durationMilliseconds(bucket.UpperBound),
+			Count: bucket.Count,
+		})
+	}
+	return res
+}
+
+func durationMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func newDBStatusRes(typ string, status dao.DBStatus) DBStatusRes {
+	var lastCheckTime int64
+	if !status.LastCheckTime.IsZero() {
+		lastCheckTime = status.LastCheckTime.Unix()
+	}
+	res := DBStatusRes{
+		Type:               typ,
+		Name:               status.Name,
+		Healthy:            status.Healthy,
+		LastCheckTime:      lastCheckTime,
+		LastError:          status.LastError,
+		MaxOpenConnections: status.Stats.MaxOpenConnections,
+		OpenConnections:    status.Stats.OpenConnections,
+		InUse:              status.Stats.InUse,
+		Idle:               status.Stats.Idle,
+		WaitCount:          status.Stats.WaitCount,
+		WaitDuration:       status.Stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      status.Stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  status.Stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  status.Stats.MaxLifetimeClosed,
+	}
+	for _, replica := range status.Replicas {
+		res.Replicas = append(res.Replicas, newDBStatusRes(typ, replica))
+	}
+	return res
+}
+
+func (s *Server) initDBRouter(g *gin.RouterGroup, readOpt apiConfigOption) {
+	g.GET("/status", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		return newDBStatusRes(string(s.sidecar.Repo.Cfg.DB.Type), s.SQLConnector.Status()), nil
+	}, readOpt))
+
+	g.GET("/metrics", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		metrics := DBQueryMetricsRes{Queries: []DBQueryStatsRes{}}
+		for _, stats := range s.SQLConnector.QueryMetrics() {
+			metrics.Queries = append(metrics.Queries, newDBQueryStatsRes(stats))
+		}
+		return metrics, nil
+	}, readOpt))
+}
@@ -0,0 +1,333 @@
+package rest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/core/service"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type UserRes struct {
+	ID         int64  `json:"id"`
+	Nickname   string `json:"nickname"`
+	Info       string `json:"info"`
+	Role       string `json:"role"`
+	Version    int64  `json:"version"`
+	CreateTime int64  `json:"create_time"`
+	UpdateTime int64  `json:"update_time"`
+}
+
+// UpdateMeReq updates the fields present, version is the one read by GET /me
+type UpdateMeReq struct {
+	Version  *int64  `json:"version" binding:"required"`
+	Nickname *string `json:"nickname"`
+	Info     *string `json:"info"`
+}
+
+// LinkAuthReq carries the proof of ownership of the auth_type
+type LinkAuthReq struct {
+	AuthType string `json:"auth_type" binding:"required"`
+	// username and email
+	Password string `json:"password"`
+	// username
+	Username string `json:"username"`
+	// email, code is sent by /auth/email/code with purpose link
+	Email string `json:"email"`
+	Code  string `json:"code"`
+	// oidc:<provider>, state and code are redirected back from the provider after /me/auths/oidc/<provider>/start
+	State string `json:"state"`
+	// tg, one of them is required
+	TelegramInitData string         `json:"telegram_init_data"`
+	TelegramWidget   map[string]any `json:"telegram_widget"`
+}
+
+type UserAuthRes struct {
+	ID            int64  `json:"id"`
+	AuthType      string `json:"auth_type"`
+	AuthID        string `json:"auth_id"`
+	CreateTime    int64  `json:"create_time"`
+	LastLoginTime int64  `json:"last_login_time"`
+}
+
+type ExportUserAuthRes struct {
+	UserAuthRes
+	DelState int64 `json:"del_state"`
+}
+
+type ExportSessionRes struct {
+	ID         int64 `json:"id"`
+	FamilyID   int64 `json:"family_id"`
+	CreateTime int64 `json:"create_time"`
+	ExpireTime int64 `json:"expire_time"`
+	RotateTime int64 `json:"rotate_time"`
+	DelState   int64 `json:"del_state"`
+}
+
+// UserExportRes is the data archive of the user, the secrets and the hashes are left out
+type UserExportRes struct {
+	ExportTime int64               `json:"export_time"`
+	User       AdminUserRes        `json:"user"`
+	UserAuths  []ExportUserAuthRes `json:"user_auths"`
+	Sessions   []ExportSessionRes  `json:"sessions"`
+	APIKeys    []APIKeyRes         `json:"api_keys"`
+	AuditLogs  []AuditLogRes       `json:"audit_logs"`
+}
+
+type TOTPEnrollmentRes struct {
+	Secret string `json:"secret"`
+	// otpauth uri shown as a qr code
+	URI string `json:"uri"`
+}
+
+type ConfirmTOTPReq struct {
+	Secret string `json:"secret" binding:"required"`
+	Code   string `json:"code" binding:"required"`
+}
+
+// TOTPCodeReq proves the second factor by a totp code or a recovery code
+type TOTPCodeReq struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type RecoveryCodesRes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func newUserRes(user *model.User) UserRes {
+	return UserRes{
+		ID:         user.ID,
+		Nickname:   user.Nickname,
+		Info:       user.Info,
+		Role:       user.Role,
+		Version:    user.Version,
+		CreateTime: user.CreateTime.Unix(),
+		UpdateTime: user.UpdateTime.Unix(),
+	}
+}
+
+func (s *Server) newUserExportRes(export *service.UserExport) UserExportRes {
+	res := UserExportRes{
+		ExportTime: time.Now().Unix(),
+		User:       newAdminUserRes(export.User),
+		UserAuths:  make([]ExportUserAuthRes, 0, len(export.UserAuths)),
+		Sessions:   make([]ExportSessionRes, 0, len(export.RefreshTokens)),
+		APIKeys:    make([]APIKeyRes, 0, len(export.APIKeys)),
+		AuditLogs:  make([]AuditLogRes, 0, len(export.AuditLogs)),
+	}
+	for _, userAuth := range export.UserAuths {
+		res.UserAuths = append(res.UserAuths, ExportUserAuthRes{
+			UserAuthRes: UserAuthRes{
+				ID:            userAuth.ID,
+				AuthType:      userAuth.AuthType,
+				AuthID:        userAuth.AuthID,
+				CreateTime:    userAuth.CreateTime.Unix(),
+				LastLoginTime: userAuth.LastLoginTime.Unix(),
+			},
+			DelState: userAuth.DelState,
+		})
+	}
+	for _, refreshToken := range export.RefreshTokens {
+		session := ExportSessionRes{
+			ID:         refreshToken.ID,
+			FamilyID:   refreshToken.FamilyID,
+			CreateTime: refreshToken.CreateTime.Unix(),
+			ExpireTime: refreshToken.ExpireTime.Unix(),
+			DelState:   refreshToken.DelState,
+		}
+		if !refreshToken.RotateTime.IsZero() {
+			session.RotateTime = refreshToken.RotateTime.Unix()
+		}
+		res.Sessions = append(res.Sessions, session)
+	}
+	for _, apiKey := range export.APIKeys {
+		res.APIKeys = append(res.APIKeys, s.newAPIKeyRes(apiKey))
+	}
+	for _, auditLog := range export.AuditLogs {
+		res.AuditLogs = append(res.AuditLogs, newAuditLogRes(auditLog))
+	}
+	return res
+}
+
+func (s *Server) initMeRouter(g *gin.RouterGroup) {
+	g.GET("", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		user, err := s.UserService.QueryByID(ctx.Ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return newUserRes(user), nil
+	}, apiNeedAuth()))
+
+	g.PATCH("", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req UpdateMeReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		user, err := s.UserService.UpdateProfile(ctx.Ctx, userID, *req.Version, req.Nickname, req.Info)
+		if err != nil {
+			return nil, err
+		}
+		return newUserRes(user), nil
+	}, apiNeedAuth()))
+
+	g.GET("/export", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		export, err := s.UserService.ExportUser(ctx.Ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%d-export.json"`, userID))
+		return s.newUserExportRes(export), nil
+	}, apiNeedAuth()))
+
+	g.GET("/auths", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		userAuths, err := s.AuthService.ListUserAuths(ctx.Ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		list := make([]UserAuthRes, 0, len(userAuths))
+		for _, userAuth := range userAuths {
+			list = append(list, UserAuthRes{
+				ID:            userAuth.ID,
+				AuthType:      userAuth.AuthType,
+				AuthID:        userAuth.AuthID,
+				CreateTime:    userAuth.CreateTime.Unix(),
+				LastLoginTime: userAuth.LastLoginTime.Unix(),
+			})
+		}
+		return list, nil
+	}, apiNeedAuth()))
+
+	g.POST("/auths", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req LinkAuthReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		switch req.AuthType {
+		case entity.AuthTypeUsername:
+			return nil, s.AuthService.LinkUsername(ctx.Ctx, userID, req.Username, req.Password)
+		case entity.AuthTypeEmail:
+			return nil, s.AuthService.LinkEmail(ctx.Ctx, userID, req.Email, req.Code, req.Password)
+		case entity.AuthTypeTelegram:
+			if req.TelegramInitData != "" {
+				return nil, s.AuthService.LinkTelegramWebApp(ctx.Ctx, userID, req.TelegramInitData)
+			}
+			fields, err := telegramWidgetFields(req.TelegramWidget)
+			if err != nil {
+				return nil, err
+			}
+			return nil, s.AuthService.LinkTelegramWidget(ctx.Ctx, userID, fields)
+		default:
+			if provider, ok := strings.CutPrefix(req.AuthType, entity.AuthTypeOIDCPrefix); ok {
+				return nil, s.AuthService.LinkOIDC(ctx.Ctx, userID, provider, req.State, req.Code)
+			}
+			return nil, cerrcode.ErrRequestParameter.Wrap("unsupported auth_type: " + req.AuthType)
+		}
+	}, apiNeedAuth()))
+
+	g.POST("/auths/oidc/:provider/start", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		authURL, err := s.AuthService.StartOIDC(ctx.Ctx, c.Param("provider"), userID)
+		if err != nil {
+			return nil, err
+		}
+		return OIDCStartRes{AuthURL: authURL}, nil
+	}, apiNeedAuth()))
+
+	g.DELETE("/auths/:id", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userAuthID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap("invalid auth id")
+		}
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.AuthService.UnlinkAuth(ctx.Ctx, userID, userAuthID)
+	}, apiNeedAuth()))
+
+	g.POST("/totp/enroll", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		enrollment, err := s.AuthService.EnrollTOTP(ctx.Ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return TOTPEnrollmentRes{Secret: enrollment.Secret, URI: enrollment.URI}, nil
+	}, apiNeedAuth()))
+
+	g.POST("/totp/confirm", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req ConfirmTOTPReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		recoveryCodes, err := s.AuthService.ConfirmTOTP(ctx.Ctx, userID, req.Secret, req.Code)
+		if err != nil {
+			return nil, err
+		}
+		return RecoveryCodesRes{RecoveryCodes: recoveryCodes}, nil
+	}, apiNeedAuth()))
+
+	g.POST("/totp/disable", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req TOTPCodeReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.AuthService.DisableTOTP(ctx.Ctx, userID, req.Code, c.ClientIP())
+	}, apiNeedAuth()))
+
+	g.POST("/totp/recovery-codes", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req TOTPCodeReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		userID, err := callerUserID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		recoveryCodes, err := s.AuthService.RegenerateRecoveryCodes(ctx.Ctx, userID, req.Code, c.ClientIP())
+		if err != nil {
+			return nil, err
+		}
+		return RecoveryCodesRes{RecoveryCodes: recoveryCodes}, nil
+	}, apiNeedAuth()))
+}
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type GrantRolePermissionReq struct {
+	Permission string `json:"permission" binding:"required"`
+}
+
+type RolePermissionsRes struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+func (s *Server) initPermissionRouter(g *gin.RouterGroup) {
+	g.GET("/permissions", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		permissions, err := s.PermissionService.ListPermissions(ctx.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		list := make([]entity.Permission, 0, len(permissions))
+		for _, permission := range permissions {
+			list = append(list, entity.Permission{
+				Name:        permission.Name,
+				Description: permission.Description,
+			})
+		}
+		return list, nil
+	}, apiNeedPermission(entity.PermissionRoleRead)))
+
+	g.GET("/roles/:role/permissions", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		role := c.Param("role")
+		permissions, err := s.PermissionService.ListRolePermissions(ctx.Ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		return RolePermissionsRes{Role: role, Permissions: permissions}, nil
+	}, apiNeedPermission(entity.PermissionRoleRead)))
+
+	g.POST("/roles/:role/permissions", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req GrantRolePermissionReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		return nil, s.PermissionService.GrantRolePermission(ctx.Ctx, c.Param("role"), req.Permission)
+	}, apiNeedPermission(entity.PermissionRoleWrite)))
+
+	g.DELETE("/roles/:role/permissions/:permission", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		return nil, s.PermissionService.RevokeRolePermission(ctx.Ctx, c.Param("role"), c.Param("permission"))
+	}, apiNeedPermission(entity.PermissionRoleWrite)))
+}
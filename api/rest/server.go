@@ -6,17 +6,19 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/core/service"
 	"github.com/zunkk/go-project-startup/internal/coreapi"
 	"github.com/zunkk/go-project-startup/internal/pkg/base"
 	"github.com/zunkk/go-project-startup/internal/pkg/entity"
 	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
-	"github.com/zunkk/go-sidecar/auth/jwt"
 	"github.com/zunkk/go-sidecar/errcode"
 	"github.com/zunkk/go-sidecar/frame"
 	glog "github.com/zunkk/go-sidecar/log"
@@ -26,6 +28,12 @@ import (
 
 var log = glog.WithModule("api")
 
+const (
+	apiKeyHeaderKey = "X-API-Key"
+	// reqctx.ReqCtx.Caller of api key callers, followed by the key prefix
+	apiKeyCallerPrefix = "api_key:"
+)
+
 func init() {
 	frame.RegisterComponents(New)
 }
@@ -170,6 +178,11 @@ func (s *Server) init() error {
 	s.router.MaxMultipartMemory = s.sidecar.Repo.Cfg.HTTP.MultipartMemory
 	s.router.Use(s.crossOriginMiddleware)
 
+	// served without the response envelope, verifiers expect a bare JWK set
+	s.router.GET("/.well-known/jwks.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, s.TokenService.JWKS())
+	})
+
 	{
 		v := s.router.Group("/api/v1")
 		{
@@ -181,9 +194,36 @@ func (s *Server) init() error {
 				return PingRes{Pong: req.Ping}, nil
 			}))
 
+			s.initAuthRouter(v.Group("/auth"))
+			s.initEmailAuthRouter(v.Group("/auth/email"))
+			s.initTelegramAuthRouter(v.Group("/auth/telegram"))
+			s.initOIDCAuthRouter(v.Group("/auth/oidc"))
+			s.initMeRouter(v.Group("/me"))
+			s.initPermissionRouter(v.Group("/admin"))
+			// granting a role may grant any permission and erasing is irreversible, so only admins can do them
+			s.initUserAdminRouter(v.Group("/admin/users"), apiNeedPermission(entity.PermissionUserRead), apiNeedPermission(entity.PermissionUserWrite), apiNeedAdmin())
+			s.initAPIKeyRouter(v.Group("/admin/api-keys"), apiNeedPermission(entity.PermissionAPIKeyRead), apiNeedPermission(entity.PermissionAPIKeyWrite))
+			// for the ipc cli
+			s.initUserAdminRouter(v.Group("/users"), apiNeedFromCli(), apiNeedFromCli(), apiNeedFromCli())
+			s.initUserCliRouter(v.Group("/users"), apiNeedFromCli())
+			s.initAPIKeyRouter(v.Group("/api-keys"), apiNeedFromCli(), apiNeedFromCli())
+			s.initLoginLockRouter(v.Group("/admin/login-locks"), apiNeedPermission(entity.PermissionUserWrite))
+			s.initLoginLockRouter(v.Group("/login-locks"), apiNeedFromCli())
+			s.initAuditRouter(v.Group("/admin/audit-logs"), apiNeedPermission(entity.PermissionAuditRead))
+			s.initAuditRouter(v.Group("/audit-logs"), apiNeedFromCli())
+			s.initDBRouter(v.Group("/admin/db"), apiNeedAdmin())
+			s.initDBRouter(v.Group("/db"), apiNeedFromCli())
+
 			{
 				g := v.Group("/config")
 				g.GET("/info", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+					// the config contains secrets
+					if err := s.AuditService.Record(ctx.Ctx, service.AuditEntry{
+						Action: entity.AuditActionConfigView,
+						Target: "config",
+					}); err != nil {
+						return nil, err
+					}
 					return s.sidecar.Repo.Cfg, nil
 				}, apiNeedFromCli()))
 			}
@@ -195,7 +235,7 @@ func (s *Server) init() error {
 func (s *Server) crossOriginMiddleware(c *gin.Context) {
 	c.Header("Access-Control-Allow-Origin", "*")
 	c.Header("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
-	c.Header("Access-Control-Allow-Headers", "token, origin, content-type, accept, is_zh")
+	c.Header("Access-Control-Allow-Headers", "token, x-api-key, origin, content-type, accept, is_zh")
 	c.Header("Allow", "HEAD,GET,POST,PUT,PATCH,DELETE,OPTIONS")
 
 	if c.Request.Method != "OPTIONS" {
@@ -205,16 +245,25 @@ func (s *Server) crossOriginMiddleware(c *gin.Context) {
 	}
 }
 
-func (s *Server) generateRequestContext(c *gin.Context) *reqctx.ReqCtx {
-	reqID := s.sidecar.UUIDGenerator.Generate()
-	ctx := reqctx.NewReqCtx(c.Request.Context(), s.sidecar.Logger, int64(reqID), "")
+func (s *Server) generateRequestContext(c *gin.Context, reqID int64) *reqctx.ReqCtx {
+	ctx := reqctx.NewReqCtx(dao.WithReqID(c.Request.Context(), reqID), s.sidecar.Logger, reqID, "")
 	return ctx
 }
 
+// callerUserID returns the user id of the authenticated caller
+func callerUserID(ctx *reqctx.ReqCtx) (int64, error) {
+	userID, err := strconv.ParseInt(ctx.Caller, 10, 64)
+	if err != nil {
+		return 0, cerrcode.ErrAuthCode.Wrap("caller is not a user")
+	}
+	return userID, nil
+}
+
 type apiConfig struct {
-	needAuth    bool
-	needAdmin   bool
-	needFromCli bool
+	needAuth        bool
+	needAdmin       bool
+	needFromCli     bool
+	needPermissions []string
 }
 
 type apiConfigOption func(*apiConfig)
@@ -231,6 +280,13 @@ func apiNeedAdmin() apiConfigOption {
 	}
 }
 
+// apiNeedPermission requires the role of the caller to be granted all the permissions
+func apiNeedPermission(permissions ...string) apiConfigOption {
+	return func(c *apiConfig) {
+		c.needPermissions = append(c.needPermissions, permissions...)
+	}
+}
+
 func apiNeedFromCli() apiConfigOption {
 	return func(c *apiConfig) {
 		c.needFromCli = true
@@ -251,7 +307,8 @@ func newAPIConfig(opts ...apiConfigOption) apiConfig {
 func (s *Server) apiHandlerWrap(handler func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error), opts ...apiConfigOption) func(c *gin.Context) {
 	cfg := newAPIConfig(opts...)
 	return func(c *gin.Context) {
-		ctx := s.generateRequestContext(c)
+		reqID := int64(s.sidecar.UUIDGenerator.Generate())
+		ctx := s.generateRequestContext(c, reqID)
 		startTime := time.Now()
 		reqURI := c.Request.URL.Path
 		clientIP := c.ClientIP()
@@ -262,25 +319,23 @@ func (s *Server) apiHandlerWrap(handler func(ctx *reqctx.ReqCtx, c *gin.Context)
 					return cerrcode.ErrAuthCode.Wrap("need from cli")
 				}
 			} else {
-				if cfg.needAuth || cfg.needAdmin {
-					token := c.GetHeader(repo.JWTTokenHeaderKey)
-					if token == "" {
-						return cerrcode.ErrAuthCode.Wrap("token is empty")
-					}
-
-					var customClaims entity.CustomClaims
-					id, err := jwt.ParseWithHMACKey(s.sidecar.Repo.Cfg.HTTP.JWTTokenHMACKey, token, &customClaims)
-					if err != nil {
-						return cerrcode.ErrAuthCode.Wrap(err.Error())
-					}
-					if id == "" {
-						return cerrcode.ErrAuthCode.Wrap("internal error: token data invalid: id is empty")
+				if cfg.needAuth || cfg.needAdmin || len(cfg.needPermissions) != 0 {
+					if apiKey := c.GetHeader(apiKeyHeaderKey); apiKey != "" {
+						if err := s.authenticateAPIKey(ctx, cfg, apiKey); err != nil {
+							return err
+						}
+					} else if err := s.authenticateToken(ctx, cfg, c.GetHeader(repo.JWTTokenHeaderKey)); err != nil {
+						return err
 					}
-
-					ctx.Caller = id
 				}
 			}
 
+			actor := ctx.Caller
+			if cfg.needFromCli {
+				actor = entity.AuditActorCLI
+			}
+			ctx.Ctx = service.WithAuditMeta(ctx.Ctx, service.AuditMeta{Actor: actor, ClientIP: clientIP, RequestID: reqID})
+
 			var err error
 			res, err = handler(ctx, c)
 			return err
@@ -316,6 +371,49 @@ func (s *Server) apiHandlerWrap(handler func(ctx *reqctx.ReqCtx, c *gin.Context)
 	}
 }
 
+func (s *Server) authenticateToken(ctx *reqctx.ReqCtx, cfg apiConfig, token string) error {
+	if token == "" {
+		return cerrcode.ErrAuthCode.Wrap("token is empty")
+	}
+
+	customClaims, err := s.TokenService.Verify(ctx.Ctx, token)
+	if err != nil {
+		return cerrcode.ErrAuthCode.Wrap(err.Error())
+	}
+
+	ctx.Caller = customClaims.Subject
+
+	if cfg.needAdmin && customClaims.Role != entity.UserRoleAdmin {
+		return cerrcode.ErrPermissionDenied.Wrap("need admin")
+	}
+	for _, permission := range cfg.needPermissions {
+		if !s.PermissionService.HasPermission(customClaims.Role, permission) {
+			return cerrcode.ErrPermissionDenied.Wrap("need permission: " + permission)
+		}
+	}
+	return nil
+}
+
+// authenticateAPIKey checks the scopes of the api key, api keys are never admin
+func (s *Server) authenticateAPIKey(ctx *reqctx.ReqCtx, cfg apiConfig, key string) error {
+	apiKey, err := s.APIKeyService.Verify(ctx.Ctx, key)
+	if err != nil {
+		return err
+	}
+
+	ctx.Caller = apiKeyCallerPrefix + apiKey.Prefix
+
+	if cfg.needAdmin {
+		return cerrcode.ErrPermissionDenied.Wrap("need admin")
+	}
+	for _, permission := range cfg.needPermissions {
+		if !s.APIKeyService.HasScope(apiKey, permission) {
+			return cerrcode.ErrPermissionDenied.Wrap("need scope: " + permission)
+		}
+	}
+	return nil
+}
+
 func (s *Server) failResponseWithErr(ctx *reqctx.ReqCtx, c *gin.Context, err error) {
 	code := errcode.DecodeError(err)
 	msg := err.Error()
@@ -328,10 +426,17 @@ func (s *Server) failResponseWithErr(ctx *reqctx.ReqCtx, c *gin.Context, err err
 		httpCode = http.StatusInternalServerError
 	}
 
-	c.JSON(httpCode, gin.H{
+	res := gin.H{
 		"code":    code,
 		"message": msg,
-	})
+	}
+	var retryAfterErr *cerrcode.RetryAfterError
+	if errors.As(err, &retryAfterErr) {
+		retryAfter := int64(retryAfterErr.RetryAfter.Seconds())
+		c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+		res["retry_after"] = retryAfter
+	}
+	c.JSON(httpCode, res)
 }
 
 func (s *Server) successResponseWithData(c *gin.Context, data any) {
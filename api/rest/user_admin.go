@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/core/service"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/reqctx"
+)
+
+type ListUsersReq struct {
+	Nickname string `form:"nickname"`
+	Role     string `form:"role"`
+	// unix seconds, [create_time_from, create_time_to)
+	CreateTimeFrom int64 `form:"create_time_from"`
+	CreateTimeTo   int64 `form:"create_time_to"`
+	// all states if absent
+	DelState *int64 `form:"del_state"`
+	// next_cursor of the previous page
+	Cursor int64 `form:"cursor"`
+	Limit  int   `form:"limit"`
+}
+
+type AdminUserRes struct {
+	UserRes
+	DelState   int64 `json:"del_state"`
+	DeleteTime int64 `json:"delete_time"`
+}
+
+type ListUsersRes struct {
+	Users []AdminUserRes `json:"users"`
+	// 0 on the last page
+	NextCursor int64 `json:"next_cursor"`
+}
+
+type SetUserRoleReq struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type CreateUserReq struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Nickname string `json:"nickname"`
+	Role     string `json:"role" binding:"required"`
+}
+
+type ResetPasswordReq struct {
+	Password string `json:"password" binding:"required"`
+}
+
+func newAdminUserRes(user *model.User) AdminUserRes {
+	res := AdminUserRes{
+		UserRes:  newUserRes(user),
+		DelState: user.DelState,
+	}
+	if !user.DeleteTime.IsZero() {
+		res.DeleteTime = user.DeleteTime.Unix()
+	}
+	return res
+}
+
+func (s *Server) initUserAdminRouter(g *gin.RouterGroup, readOpt apiConfigOption, writeOpt apiConfigOption, adminOpt apiConfigOption) {
+	g.GET("", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req ListUsersReq
+		if err := c.ShouldBindQuery(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		filter := service.UserFilter{
+			Nickname: req.Nickname,
+			Role:     req.Role,
+			DelState: req.DelState,
+		}
+		if req.CreateTimeFrom != 0 {
+			filter.CreateTimeFrom = time.Unix(req.CreateTimeFrom, 0)
+		}
+		if req.CreateTimeTo != 0 {
+			filter.CreateTimeTo = time.Unix(req.CreateTimeTo, 0)
+		}
+		users, nextCursor, err := s.UserService.ListUsers(ctx.Ctx, filter, req.Cursor, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+		list := make([]AdminUserRes, 0, len(users))
+		for _, user := range users {
+			list = append(list, newAdminUserRes(user))
+		}
+		return ListUsersRes{Users: list, NextCursor: nextCursor}, nil
+	}, readOpt))
+
+	g.DELETE("/:id", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap("invalid user id")
+		}
+		return nil, s.UserService.DeleteUser(ctx.Ctx, ctx.Caller, userID)
+	}, writeOpt))
+
+	g.POST("/:id/restore", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap("invalid user id")
+		}
+		if err := s.UserService.RestoreUser(ctx.Ctx, ctx.Caller, userID); err != nil {
+			return nil, err
+		}
+		return s.queryAdminUserWritten(ctx, userID)
+	}, writeOpt))
+
+	g.PUT("/:id/role", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req SetUserRoleReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap("invalid user id")
+		}
+		if err := s.UserService.SetUserRole(ctx.Ctx, ctx.Caller, userID, req.Role); err != nil {
+			return nil, err
+		}
+		return s.queryAdminUserWritten(ctx, userID)
+	}, adminOpt))
+
+	g.POST("/:id/erase", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap("invalid user id")
+		}
+		return nil, s.UserService.EraseUser(ctx.Ctx, ctx.Caller, userID)
+	}, adminOpt))
+}
+
+// initUserCliRouter registers the routes for the operators on the host, they work without any admin account
+func (s *Server) initUserCliRouter(g *gin.RouterGroup, opt apiConfigOption) {
+	g.POST("", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req CreateUserReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		user, err := s.AuthService.CreateUserByUsername(ctx.Ctx, req.Username, req.Password, req.Nickname, req.Role)
+		if err != nil {
+			return nil, err
+		}
+		return newAdminUserRes(user), nil
+	}, opt))
+
+	g.PUT("/:id/password", s.apiHandlerWrap(func(ctx *reqctx.ReqCtx, c *gin.Context) (res any, err error) {
+		var req ResetPasswordReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+		}
+		userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return nil, cerrcode.ErrRequestParameter.Wrap("invalid user id")
+		}
+		return nil, s.AuthService.ResetPassword(ctx.Ctx, userID, req.Password)
+	}, opt))
+}
+
+// queryAdminUserWritten returns the user just written by the admin, read from the primary so a lagging replica is not shown
+func (s *Server) queryAdminUserWritten(ctx *reqctx.ReqCtx, userID int64) (AdminUserRes, error) {
+	user, err := s.UserService.QueryByID(dao.ForcePrimary(ctx.Ctx), userID)
+	if err != nil {
+		return AdminUserRes{}, err
+	}
+	return newAdminUserRes(user), nil
+}
@@ -1,20 +1,11 @@
 package build
 
-import (
-	"context"
-	_ "embed"
+import "embed"
 
-	"github.com/pkg/errors"
-	"github.com/stephenafamo/bob"
-)
+// MigrationsDir is the dir of Migrations, the files of each db type are in the sub dir named by the type
+const MigrationsDir = "migrations"
 
-//go:embed ddl.sql
-var DDL string
-
-func TryCreateDDLTables(ctx context.Context, db *bob.DB) error {
-	_, err := db.ExecContext(ctx, DDL)
-	if err != nil {
-		return errors.Wrap(err, "failed to create ddl tables")
-	}
-	return nil
-}
+// Migrations are the db schema migrations, named <version>_<name>.<up|down>.sql
+//
+//go:embed migrations/*/*.sql
+var Migrations embed.FS
@@ -0,0 +1,3 @@
+-- 删除全部表, 数据不可恢复
+drop table if exists "user_auth";
+drop table if exists "user";
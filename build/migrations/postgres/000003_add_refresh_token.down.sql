@@ -0,0 +1,2 @@
+drop table "refresh_token";
+alter table "user" drop column "token_generation";
@@ -0,0 +1 @@
+drop table "verification_code";
@@ -0,0 +1 @@
+drop index user_auth_active_uindex;
@@ -0,0 +1,2 @@
+-- 同一认证渠道的账号只能绑定一个有效用户
+create unique index user_auth_active_uindex on "user_auth" ("auth_type", "auth_id") where "del_state" = 0;
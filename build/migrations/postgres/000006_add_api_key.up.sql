@@ -0,0 +1,30 @@
+-- API key, 供定时任务和其他服务调用
+create table "api_key"
+(
+    "id"             bigint        not null
+        constraint api_key_pk
+            primary key,
+    "create_time"    timestamptz   not null,
+    "update_time"    timestamp     not null,
+    "delete_time"    timestamp     not null,
+    -- 0:active 1:deleted(已吊销)
+    "del_state"      bigint        not null default 0,
+    "version"        bigint        not null default 0,
+
+    -- 名称, 用于标识调用方
+    "name"           varchar(64)   not null default '',
+    -- key 的前缀, 明文保存, 用于查找 key
+    "prefix"         varchar(16)   not null default '',
+    -- key 的 sha256 值, 不保存明文
+    "key_hash"       varchar(64)   not null default '',
+    -- 授予的权限, 逗号分隔, 对应 permission.name
+    "scopes"         varchar(1024) not null default '',
+    -- 创建者的用户id, 通过 cli 创建时为0
+    "creator_id"     bigint        not null default 0,
+    -- 过期时间, 零值表示永不过期
+    "expire_time"    timestamp     not null,
+    -- 上一次使用时间
+    "last_used_time" timestamp     not null
+);
+
+create unique index api_key_prefix_uindex on "api_key" ("prefix");
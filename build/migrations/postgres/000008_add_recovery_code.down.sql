@@ -0,0 +1 @@
+drop table "recovery_code";
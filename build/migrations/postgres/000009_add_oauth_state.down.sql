@@ -0,0 +1,2 @@
+drop table "oauth_state";
+alter table "user_auth" alter column "auth_id" type varchar(64);
@@ -0,0 +1,34 @@
+-- 认证类型新增 totp: 两步验证 和 oidc:<provider>: 第三方登录
+-- oidc 的 auth_id 为 provider 的用户id(sub), 最长 255
+alter table "user_auth" alter column "auth_id" type varchar(255);
+
+-- 第三方登录的授权请求, 回调时校验 state 并取出 nonce 和 PKCE code_verifier
+create table "oauth_state"
+(
+    "id"            bigint       not null
+        constraint oauth_state_pk
+            primary key,
+    "create_time"   timestamptz  not null,
+    "update_time"   timestamp    not null,
+    "delete_time"   timestamp    not null,
+    -- 0:active 1:deleted
+    "del_state"     bigint       not null default 0,
+    "version"       bigint       not null default 0,
+
+    -- 第三方名称, 对应配置中的 provider name
+    "provider"      varchar(20)  not null default '',
+    -- state 的 sha256 值, 不保存明文
+    "state_hash"    varchar(64)  not null default '',
+    -- id token 中需要带回的 nonce
+    "nonce"         varchar(64)  not null default '',
+    -- 加密后的 PKCE code_verifier
+    "code_verifier" varchar(255) not null default '',
+    -- 绑定到已有用户时为用户id, 登录时为0
+    "user_id"       bigint       not null default 0,
+    -- 过期时间
+    "expire_time"   timestamp    not null,
+    -- 使用时间, 零值表示未使用
+    "use_time"      timestamp    not null
+);
+
+create unique index oauth_state_state_hash_uindex on "oauth_state" ("state_hash");
@@ -0,0 +1,2 @@
+drop table "role_permission";
+drop table "permission";
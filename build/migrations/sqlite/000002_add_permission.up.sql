@@ -0,0 +1,43 @@
+-- 权限
+create table "permission"
+(
+    "id"          bigint       not null
+        constraint permission_pk
+            primary key,
+    "create_time" timestamp    not null,
+    "update_time" timestamp    not null,
+    "delete_time" timestamp    not null,
+    -- 0:active 1:deleted
+    "del_state"   bigint       not null default 0,
+    "version"     bigint       not null default 0,
+
+    -- 权限标识, 格式: 资源:操作, 例如 user:write
+    "name"        varchar(64)  not null default '',
+    -- 权限描述
+    "description" varchar(255) not null default ''
+);
+
+create unique index permission_name_uindex on "permission" ("name");
+
+-- 角色权限
+-- 关联关系: role - permission ：n - n
+-- admin 角色默认拥有全部权限, 不需要在此表中配置
+create table "role_permission"
+(
+    "id"          bigint      not null
+        constraint role_permission_pk
+            primary key,
+    "create_time" timestamp   not null,
+    "update_time" timestamp   not null,
+    "delete_time" timestamp   not null,
+    -- 0:active 1:deleted
+    "del_state"   bigint      not null default 0,
+    "version"     bigint      not null default 0,
+
+    -- 角色, 对应 user.role
+    "role"        varchar(20) not null default '',
+    -- 权限标识, 对应 permission.name
+    "permission"  varchar(64) not null default ''
+);
+
+create unique index role_permission_uindex on "role_permission" ("role", "permission");
@@ -0,0 +1,61 @@
+-- token_generation: token 代数, 签发的 access token 中携带该值, 自增后之前签发的 token 全部失效(登出所有设备)
+-- 同时重建表: 基线建表语句中 create_time 为 timestamptz, sqlite 驱动不会把它解析为时间, sqlite 不支持修改列类型
+-- token_generation 前不能有含逗号的注释, 否则 sqlite 回滚时删除该列无法解析建表语句
+create table "user_new"
+(
+    "id"                               bigint       not null
+        constraint user_pk
+            primary key,
+    "create_time"                      timestamp    not null,
+    "update_time"                      timestamp    not null,
+    "delete_time"                      timestamp    not null,
+    -- 删除状态(使用软删除)
+    -- 0:active 1:deleted 2:erased(个人数据已清除, 不可恢复)
+    "del_state"                        bigint       not null default 0,
+    "version"                          bigint       not null default 0,
+
+    -- 用户名
+    "nickname"                         varchar(255) not null default '',
+    -- 用户信息
+    "info"                             varchar(255) not null default '',
+    -- 角色
+    "role"                             varchar(20)  not null default '',
+    "token_generation"                 bigint       not null default 0
+);
+
+insert into "user_new" ("id", "create_time", "update_time", "delete_time", "del_state", "version", "nickname", "info", "role")
+select "id", "create_time", "update_time", "delete_time", "del_state", "version", "nickname", "info", "role"
+from "user";
+drop table "user";
+alter table "user_new" rename to "user";
+
+-- 刷新 token
+-- 关联关系: user - refresh_token ：1 - n
+-- 每次刷新都会签发新 token 并作废旧 token, 同一登录会话签发的 token 属于同一个 family
+create table "refresh_token"
+(
+    "id"          bigint      not null
+        constraint refresh_token_pk
+            primary key,
+    "create_time" timestamp   not null,
+    "update_time" timestamp   not null,
+    "delete_time" timestamp   not null,
+    -- 0:active 1:deleted(已吊销)
+    "del_state"   bigint      not null default 0,
+    "version"     bigint      not null default 0,
+
+    -- 关联的用户id
+    "user_id"     bigint      not null default 0,
+    -- 所属的登录会话, 取值为该会话第一个 token 的 id
+    "family_id"   bigint      not null default 0,
+    -- token 的 sha256 值, 不保存明文
+    "token_hash"  varchar(64) not null default '',
+    -- 过期时间
+    "expire_time" timestamp   not null,
+    -- 被轮换(使用)的时间, 未使用时为零值; 已轮换的 token 再次使用视为泄露, 整个 family 会被吊销
+    "rotate_time" timestamp   not null
+);
+
+create unique index refresh_token_token_hash_uindex on "refresh_token" ("token_hash");
+create index refresh_token_user_id_index on "refresh_token" ("user_id");
+create index refresh_token_family_id_index on "refresh_token" ("family_id");
@@ -0,0 +1,33 @@
+-- 验证码
+-- 邮箱注册/登录的验证码, 以及重置密码链接中的 token
+create table "verification_code"
+(
+    "id"          bigint       not null
+        constraint verification_code_pk
+            primary key,
+    "create_time" timestamp    not null,
+    "update_time" timestamp    not null,
+    "delete_time" timestamp    not null,
+    -- 0:active 1:deleted(已作废)
+    "del_state"   bigint       not null default 0,
+    "version"     bigint       not null default 0,
+
+    -- 用途
+    -- register: 注册
+    -- login: 登录
+    -- link: 绑定到已有用户
+    -- reset_password: 重置密码
+    "purpose"     varchar(20)  not null default '',
+    -- 接收方, 例如邮箱地址
+    "target"      varchar(255) not null default '',
+    -- 验证码的 sha256 值, 不保存明文
+    "code_hash"   varchar(64)  not null default '',
+    -- 过期时间
+    "expire_time" timestamp    not null,
+    -- 校验失败次数, 超过上限后作废
+    "attempts"    bigint       not null default 0,
+    -- 使用时间, 未使用时为零值
+    "use_time"    timestamp    not null
+);
+
+create index verification_code_target_index on "verification_code" ("purpose", "target");
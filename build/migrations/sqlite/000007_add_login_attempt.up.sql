@@ -0,0 +1,27 @@
+-- 登录失败记录, 用于防止暴力破解
+create table "login_attempt"
+(
+    "id"             bigint       not null
+        constraint login_attempt_pk
+            primary key,
+    "create_time"    timestamp    not null,
+    "update_time"    timestamp    not null,
+    "delete_time"    timestamp    not null,
+    -- 0:active 1:deleted
+    "del_state"      bigint       not null default 0,
+    "version"        bigint       not null default 0,
+
+    -- 维度
+    -- account: 账号, subject 为 auth_type:auth_id
+    -- ip: 客户端ip, subject 为 ip
+    "scope"          varchar(20)  not null default '',
+    "subject"        varchar(255) not null default '',
+    -- 连续失败次数, 登录成功或解锁后清零
+    "fail_count"     bigint       not null default 0,
+    -- 上一次失败时间
+    "last_fail_time" timestamp    not null,
+    -- 锁定截止时间, 零值表示未锁定
+    "lock_until"     timestamp    not null
+);
+
+create unique index login_attempt_uindex on "login_attempt" ("scope", "subject");
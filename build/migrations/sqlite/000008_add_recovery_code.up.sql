@@ -0,0 +1,21 @@
+-- 两步验证的恢复码, 每个只能使用一次
+create table "recovery_code"
+(
+    "id"          bigint      not null
+        constraint recovery_code_pk
+            primary key,
+    "create_time" timestamp   not null,
+    "update_time" timestamp   not null,
+    "delete_time" timestamp   not null,
+    -- 0:active 1:deleted(重新生成或关闭两步验证后作废)
+    "del_state"   bigint      not null default 0,
+    "version"     bigint      not null default 0,
+
+    "user_id"     bigint      not null default 0,
+    -- 恢复码的 sha256 值, 不保存明文
+    "code_hash"   varchar(64) not null default '',
+    -- 使用时间, 零值表示未使用
+    "use_time"    timestamp   not null
+);
+
+create index recovery_code_user_id_index on "recovery_code" ("user_id");
@@ -0,0 +1,2 @@
+-- sqlite 不限制 varchar 的长度, 不需要恢复 auth_id 的长度
+drop table "oauth_state";
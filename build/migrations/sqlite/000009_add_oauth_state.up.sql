@@ -0,0 +1,82 @@
+-- 重建表: 基线建表语句中 create_time 为 timestamptz, sqlite 驱动不会把它解析为时间, sqlite 不支持修改列类型
+create table "user_auth_new"
+(
+    "id"              bigint       not null
+        constraint user_auth_pk
+            primary key,
+    "create_time"     timestamp    not null,
+    "update_time"     timestamp    not null,
+    "delete_time"     timestamp    not null,
+    -- 0:active 1:deleted
+    "del_state"       bigint       not null default 0,
+    "version"         bigint       not null default 0,
+
+    -- 关联的用户id
+    "user_id"         bigint       not null default 0,
+    -- 认证类型
+    -- username
+    -- tg: telegram
+    -- email: 邮箱
+    -- totp: 两步验证, 不能单独用于登录
+    -- oidc:<provider>: 第三方登录, 例如 oidc:google
+    "auth_type"       varchar(20)  not null default '',
+    -- 认证渠道的id
+    -- username: 用户名
+    -- tg: tg的用户id
+    -- email: 邮箱地址
+    -- totp: 用户id
+    -- oidc:<provider>: provider 的用户id(sub)
+    "auth_id"         varchar(255) not null default '',
+    -- 认证渠道的token
+    -- username: 密码
+    -- tg: 无(登录时校验 Telegram 签名, tg 已经做完这一步认证了)
+    -- email: 密码
+    -- totp: 加密后的密钥
+    -- oidc:<provider>: 无(登录时校验 id token)
+    "auth_token"      varchar(255) not null default '',
+    -- 上一次登录时间
+    -- totp: 上一次使用的验证码所在的时间窗口, 防止重放
+    "last_login_time" timestamp    not null
+);
+
+insert into "user_auth_new"
+select "id", "create_time", "update_time", "delete_time", "del_state", "version", "user_id", "auth_type", "auth_id", "auth_token", "last_login_time"
+from "user_auth";
+drop table "user_auth";
+alter table "user_auth_new" rename to "user_auth";
+
+create index user_auth_type_index on "user_auth" ("auth_type", "auth_id");
+-- 同一认证渠道的账号只能绑定一个有效用户
+create unique index user_auth_active_uindex on "user_auth" ("auth_type", "auth_id") where "del_state" = 0;
+create index user_auth_user_id_index on "user_auth" ("user_id", "auth_type");
+
+-- 第三方登录的授权请求, 回调时校验 state 并取出 nonce 和 PKCE code_verifier
+create table "oauth_state"
+(
+    "id"            bigint       not null
+        constraint oauth_state_pk
+            primary key,
+    "create_time"   timestamp    not null,
+    "update_time"   timestamp    not null,
+    "delete_time"   timestamp    not null,
+    -- 0:active 1:deleted
+    "del_state"     bigint       not null default 0,
+    "version"       bigint       not null default 0,
+
+    -- 第三方名称, 对应配置中的 provider name
+    "provider"      varchar(20)  not null default '',
+    -- state 的 sha256 值, 不保存明文
+    "state_hash"    varchar(64)  not null default '',
+    -- id token 中需要带回的 nonce
+    "nonce"         varchar(64)  not null default '',
+    -- 加密后的 PKCE code_verifier
+    "code_verifier" varchar(255) not null default '',
+    -- 绑定到已有用户时为用户id, 登录时为0
+    "user_id"       bigint       not null default 0,
+    -- 过期时间
+    "expire_time"   timestamp    not null,
+    -- 使用时间, 零值表示未使用
+    "use_time"      timestamp    not null
+);
+
+create unique index oauth_state_state_hash_uindex on "oauth_state" ("state_hash");
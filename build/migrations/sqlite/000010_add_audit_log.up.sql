@@ -0,0 +1,30 @@
+-- 审计日志, 记录安全相关操作, 超过保留期限后物理删除
+create table "audit_log"
+(
+    "id"          bigint       not null
+        constraint audit_log_pk
+            primary key,
+    "create_time" timestamp    not null,
+    "update_time" timestamp    not null,
+    "delete_time" timestamp    not null,
+    -- 0:active 1:deleted
+    "del_state"   bigint       not null default 0,
+    "version"     bigint       not null default 0,
+
+    -- 操作者: 用户id, api_key:<prefix>, cli(本机命令行), 匿名时为空
+    "actor"       varchar(64)  not null default '',
+    -- 操作类型, 如 login, user.delete
+    "action"      varchar(64)  not null default '',
+    -- 操作对象, 如 user:<id>
+    "target"      varchar(255) not null default '',
+    -- 变更内容, json: {"before":{...},"after":{...}}, 只包含变化的字段
+    "diff"        text         not null default '',
+    -- 客户端ip, cli 调用时为空
+    "client_ip"   varchar(64)  not null default '',
+    -- 请求id, 对应 api 日志
+    "request_id"  bigint       not null default 0
+);
+
+create index audit_log_create_time_index on "audit_log" ("create_time");
+create index audit_log_actor_index on "audit_log" ("actor", "create_time");
+create index audit_log_target_index on "audit_log" ("target", "create_time");
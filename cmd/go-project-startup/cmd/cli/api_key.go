@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/urfave/cli/v2"
+
+	"github.com/zunkk/go-project-startup/api/rest"
+)
+
+var apiKeyCommand = &cli.Command{
+	Name:  "api-key",
+	Usage: "The api key manage commands",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "create",
+			Usage:  "Create an api key, the key is only printed once",
+			Action: apiKeyCreate,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "name",
+					Usage:    "Api key name",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:     "scope",
+					Usage:    "Permission granted to the key, can be repeated",
+					Required: true,
+				},
+				&cli.DurationFlag{
+					Name:  "expire",
+					Usage: "Valid duration of the key, 0 means never expires",
+				},
+			},
+		},
+		{
+			Name:   "list",
+			Usage:  "List api keys",
+			Action: apiKeyList,
+		},
+		{
+			Name:   "revoke",
+			Usage:  "Revoke an api key",
+			Action: apiKeyRevoke,
+			Flags: []cli.Flag{
+				&cli.Int64Flag{
+					Name:     "id",
+					Usage:    "Api key id",
+					Required: true,
+				},
+			},
+		},
+	},
+}
+
+func apiKeyCreate(ctx *cli.Context) error {
+	req := rest.CreateAPIKeyReq{
+		Name:   ctx.String("name"),
+		Scopes: ctx.StringSlice("scope"),
+	}
+	if expire := ctx.Duration("expire"); expire > 0 {
+		req.ExpireTime = time.Now().Add(expire).Unix()
+	}
+	res, err := doRequest[rest.CreateAPIKeyRes](http.MethodPost, "/api-keys", func(r *resty.Request) {
+		r.SetBody(req)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("api key %s(id: %d) created, store it safely, it can not be shown again:\n%s\n", res.Name, res.ID, res.Key)
+	return nil
+}
+
+func apiKeyList(ctx *cli.Context) error {
+	res, err := doRequest[[]rest.APIKeyRes](http.MethodGet, "/api-keys", nil)
+	if err != nil {
+		return err
+	}
+	return PrettyPrint(res)
+}
+
+func apiKeyRevoke(ctx *cli.Context) error {
+	if _, err := doRequest[emptyRes](http.MethodDelete, "/api-keys/"+strconv.FormatInt(ctx.Int64("id"), 10), nil); err != nil {
+		return err
+	}
+	fmt.Println("api key revoked")
+	return nil
+}
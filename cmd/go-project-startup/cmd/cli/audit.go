@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/urfave/cli/v2"
+
+	"github.com/zunkk/go-project-startup/api/rest"
+)
+
+var auditCommand = &cli.Command{
+	Name:  "audit",
+	Usage: "The audit log commands",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List audit logs, newest first",
+			Action: auditList,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "actor",
+					Usage: "User id, api_key:<prefix> or cli",
+				},
+				&cli.StringFlag{
+					Name:  "action",
+					Usage: "Action, e.g. login, user.delete",
+				},
+				&cli.StringFlag{
+					Name:  "target",
+					Usage: "Target, e.g. user:<id>",
+				},
+				&cli.Int64Flag{
+					Name:  "cursor",
+					Usage: "next_cursor of the previous page",
+				},
+				&cli.IntFlag{
+					Name:  "limit",
+					Usage: "Page size",
+					Value: 20,
+				},
+			},
+		},
+	},
+}
+
+func auditList(ctx *cli.Context) error {
+	res, err := doRequest[rest.ListAuditLogsRes](http.MethodGet, "/audit-logs", func(r *resty.Request) {
+		r.SetQueryParams(map[string]string{
+			"actor":  ctx.String("actor"),
+			"action": ctx.String("action"),
+			"target": ctx.String("target"),
+			"cursor": strconv.FormatInt(ctx.Int64("cursor"), 10),
+			"limit":  strconv.Itoa(ctx.Int("limit")),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return PrettyPrint(res)
+}
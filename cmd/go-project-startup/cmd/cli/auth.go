@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/urfave/cli/v2"
+
+	"github.com/zunkk/go-project-startup/api/rest"
+)
+
+var authCommand = &cli.Command{
+	Name:  "auth",
+	Usage: "The auth manage commands",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "rotate-key",
+			Usage:  "Rotate the jwt signing key, tokens signed by the previous key stay valid until they expire",
+			Action: authRotateKey,
+		},
+		{
+			Name:   "unlock",
+			Usage:  "Clear the failed logins of a user or a client ip",
+			Action: authUnlock,
+			Flags: []cli.Flag{
+				&cli.Int64Flag{
+					Name:  "user-id",
+					Usage: "User id",
+				},
+				&cli.StringFlag{
+					Name:  "ip",
+					Usage: "Client ip",
+				},
+			},
+		},
+	},
+}
+
+func authRotateKey(ctx *cli.Context) error {
+	res, err := doRequest[rest.JWTKeyRes](http.MethodPost, "/auth/keys/rotate", nil)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("new jwt signing key: %s(%s)\n", res.KID, res.Algorithm)
+	return nil
+}
+
+func authUnlock(ctx *cli.Context) error {
+	req := rest.UnlockLoginReq{
+		UserID: ctx.Int64("user-id"),
+		IP:     ctx.String("ip"),
+	}
+	if _, err := doRequest[emptyRes](http.MethodPost, "/login-locks/unlock", func(r *resty.Request) {
+		r.SetBody(req)
+	}); err != nil {
+		return err
+	}
+	fmt.Println("unlocked")
+	return nil
+}
@@ -43,6 +43,11 @@ var Command = &cli.Command{
 			},
 		},
 		configCommand,
+		authCommand,
+		apiKeyCommand,
+		auditCommand,
+		userCommand,
+		dbCommand,
 	},
 }
 
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/urfave/cli/v2"
+
+	"github.com/zunkk/go-project-startup/api/rest"
+)
+
+var dbCommand = &cli.Command{
+	Name:  "db",
+	Usage: "The db commands",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "status",
+			Usage:  "Show the db health and the connection pool statistics",
+			Action: dbStatus,
+		},
+		{
+			Name:   "metrics",
+			Usage:  "Show the count and the latency of the queries by name",
+			Action: dbMetrics,
+		},
+	},
+}
+
+func dbStatus(ctx *cli.Context) error {
+	res, err := doRequest[rest.DBStatusRes](http.MethodGet, "/db/status", func(r *resty.Request) {})
+	if err != nil {
+		return err
+	}
+	return PrettyPrint(res)
+}
+
+func dbMetrics(ctx *cli.Context) error {
+	res, err := doRequest[rest.DBQueryMetricsRes](http.MethodGet, "/db/metrics", func(r *resty.Request) {})
+	if err != nil {
+		return err
+	}
+	return PrettyPrint(res)
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/zunkk/go-project-startup/api/rest"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+)
+
+var userCommand = &cli.Command{
+	Name:  "user",
+	Usage: "The user manage commands",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "create-admin",
+			Usage:  "Create an admin user with a username login",
+			Action: userCreateAdmin,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "username",
+					Usage:    "Username",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "password",
+					Usage: "Password, a random one is generated and printed if absent",
+				},
+				&cli.StringFlag{
+					Name:  "nickname",
+					Usage: "Nickname, defaults to the username",
+				},
+			},
+		},
+		{
+			Name:   "set-role",
+			Usage:  "Set the role of a user, the login sessions of the user are revoked",
+			Action: userSetRole,
+			Flags: []cli.Flag{
+				&cli.Int64Flag{
+					Name:     "id",
+					Usage:    "User id",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "role",
+					Usage:    "Role, e.g. admin, user",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "reset-password",
+			Usage:  "Reset the password of the username and email logins of a user, the login sessions are revoked and the user is unlocked",
+			Action: userResetPassword,
+			Flags: []cli.Flag{
+				&cli.Int64Flag{
+					Name:     "id",
+					Usage:    "User id",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "password",
+					Usage: "New password, a random one is generated and printed if absent",
+				},
+			},
+		},
+		{
+			Name:   "list",
+			Usage:  "List users, newest first",
+			Action: userList,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "nickname",
+					Usage: "Part of the nickname, case insensitive",
+				},
+				&cli.StringFlag{
+					Name:  "role",
+					Usage: "Role",
+				},
+				&cli.Int64Flag{
+					Name:  "cursor",
+					Usage: "next_cursor of the previous page",
+				},
+				&cli.IntFlag{
+					Name:  "limit",
+					Usage: "Page size",
+					Value: 20,
+				},
+			},
+		},
+	},
+}
+
+func userCreateAdmin(ctx *cli.Context) error {
+	password, generated, err := passwordOrRandom(ctx.String("password"))
+	if err != nil {
+		return err
+	}
+	req := rest.CreateUserReq{
+		Username: ctx.String("username"),
+		Password: password,
+		Nickname: ctx.String("nickname"),
+		Role:     entity.UserRoleAdmin,
+	}
+	res, err := doRequest[rest.AdminUserRes](http.MethodPost, "/users", func(r *resty.Request) {
+		r.SetBody(req)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("admin user %s(id: %d) created\n", req.Username, res.ID)
+	if generated {
+		fmt.Printf("password: %s\n", password)
+	}
+	return nil
+}
+
+func userSetRole(ctx *cli.Context) error {
+	req := rest.SetUserRoleReq{
+		Role: ctx.String("role"),
+	}
+	if _, err := doRequest[emptyRes](http.MethodPut, fmt.Sprintf("/users/%d/role", ctx.Int64("id")), func(r *resty.Request) {
+		r.SetBody(req)
+	}); err != nil {
+		return err
+	}
+	fmt.Println("role updated")
+	return nil
+}
+
+func userResetPassword(ctx *cli.Context) error {
+	password, generated, err := passwordOrRandom(ctx.String("password"))
+	if err != nil {
+		return err
+	}
+	req := rest.ResetPasswordReq{
+		Password: password,
+	}
+	if _, err := doRequest[emptyRes](http.MethodPut, fmt.Sprintf("/users/%d/password", ctx.Int64("id")), func(r *resty.Request) {
+		r.SetBody(req)
+	}); err != nil {
+		return err
+	}
+	fmt.Println("password reset")
+	if generated {
+		fmt.Printf("password: %s\n", password)
+	}
+	return nil
+}
+
+func userList(ctx *cli.Context) error {
+	res, err := doRequest[rest.ListUsersRes](http.MethodGet, "/users", func(r *resty.Request) {
+		r.SetQueryParams(map[string]string{
+			"nickname": ctx.String("nickname"),
+			"role":     ctx.String("role"),
+			"cursor":   strconv.FormatInt(ctx.Int64("cursor"), 10),
+			"limit":    strconv.Itoa(ctx.Int("limit")),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return PrettyPrint(res)
+}
+
+// passwordOrRandom keeps the password out of the shell history unless given explicitly
+func passwordOrRandom(password string) (string, bool, error) {
+	if password != "" {
+		return password, false, nil
+	}
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", false, errors.Wrap(err, "failed to generate password")
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), true, nil
+}
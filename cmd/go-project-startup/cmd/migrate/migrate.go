@@ -0,0 +1,152 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	"github.com/zunkk/go-project-startup/build"
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	internalconfig "github.com/zunkk/go-project-startup/internal/pkg/config"
+	"github.com/zunkk/go-sidecar/repo"
+)
+
+// Command is the db schema migration commands, they connect to the db of the config directly
+var Command = &cli.Command{
+	Name:  "migrate",
+	Usage: "The db schema migration commands",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "up",
+			Usage:  "Apply the pending migrations, the server also does it on start",
+			Action: up,
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:  "steps",
+					Usage: "Number of migrations to apply, 0 means all",
+				},
+			},
+		},
+		{
+			Name:   "down",
+			Usage:  "Roll back the latest applied migrations, stop the server first",
+			Action: down,
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:  "steps",
+					Usage: "Number of migrations to roll back",
+					Value: 1,
+				},
+			},
+		},
+		{
+			Name:   "status",
+			Usage:  "Show the state of the migrations",
+			Action: status,
+		},
+		{
+			Name:      "create",
+			Usage:     "Create an empty up and down migration file of every db type in the source tree",
+			ArgsUsage: "<name>",
+			Action:    create,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "dir",
+					Usage: "Migration source dir",
+					Value: "build/" + build.MigrationsDir,
+				},
+			},
+		},
+	},
+}
+
+func up(ctx *cli.Context) error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(ctx.Context, ctx.Int("steps"))
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Println("no pending migration")
+	}
+	for _, migration := range applied {
+		fmt.Printf("applied %s\n", migration)
+	}
+	return nil
+}
+
+func down(ctx *cli.Context) error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	rolledBack, err := migrator.Down(ctx.Context, ctx.Int("steps"))
+	if err != nil {
+		return err
+	}
+	if len(rolledBack) == 0 {
+		fmt.Println("no applied migration")
+	}
+	for _, migration := range rolledBack {
+		fmt.Printf("rolled back %s\n", migration)
+	}
+	return nil
+}
+
+func status(ctx *cli.Context) error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	statuses, err := migrator.Status(ctx.Context)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLY TIME")
+	for _, s := range statuses {
+		applyTime := "-"
+		if !s.ApplyTime.IsZero() {
+			applyTime = s.ApplyTime.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", s.Version, s.Name, s.State, applyTime)
+	}
+	return w.Flush()
+}
+
+func create(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("migration name is required")
+	}
+	files, err := dao.CreateMigration(ctx.String("dir"), ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		fmt.Printf("created %s\n", file)
+	}
+	return nil
+}
+
+func newMigrator() (*dao.Migrator, error) {
+	rep, err := repo.Load(repo.RootPath, internalconfig.DefaultConfig)
+	if err != nil {
+		return nil, err
+	}
+	db, err := dao.OpenDB(rep.RepoPath, rep.Cfg.DB)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := dao.LoadBuiltinMigrations(rep.Cfg.DB.Type)
+	if err != nil {
+		return nil, err
+	}
+	return dao.NewMigrator(db, migrations), nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/zunkk/go-project-startup/cmd/go-project-startup/cmd"
 	clicmd "github.com/zunkk/go-project-startup/cmd/go-project-startup/cmd/cli"
 	configcmd "github.com/zunkk/go-project-startup/cmd/go-project-startup/cmd/config"
+	migratecmd "github.com/zunkk/go-project-startup/cmd/go-project-startup/cmd/migrate"
 	"github.com/zunkk/go-project-startup/internal/pkg/config"
 	"github.com/zunkk/go-sidecar/repo"
 )
@@ -60,6 +61,7 @@ func main() {
 			},
 		},
 		configcmd.Command,
+		migratecmd.Command,
 		clicmd.Command,
 	}
 
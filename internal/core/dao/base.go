@@ -4,11 +4,10 @@ import (
 	"context"
 
 	"github.com/jmoiron/sqlx"
-	"github.com/pkg/errors"
 	"github.com/stephenafamo/bob"
 
-	"github.com/zunkk/go-project-startup/build"
 	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-project-startup/internal/pkg/config"
 	"github.com/zunkk/go-sidecar/db/sql"
 	"github.com/zunkk/go-sidecar/frame"
 	glog "github.com/zunkk/go-sidecar/log"
@@ -20,33 +19,70 @@ func init() {
 	frame.RegisterComponents(NewSQLConnector)
 }
 
-type DBAction func(dbTX bob.Transaction) error
-
 type SQLConnector struct {
 	sidecar *base.CustomSidecar
-	DB      *bob.DB
+	// DB is not instrumented and can not insert the models, the services use the executors
+	DB *bob.DB
+	// Executor runs on the transaction carried by the context, or DB if there is none
+	Executor bob.Executor
+	// ReadExecutor is Executor spreading the reads out of transactions over the healthy replicas,
+	// only for the reads that can see a lagging replica, see ForcePrimary
+	ReadExecutor bob.Executor
+	health       dbHealth
+	replicas     []*replica
+	db           instrumentedDB
+	instrument   *queryInstrumenter
 }
 
 func NewSQLConnector(sidecar *base.CustomSidecar) (*SQLConnector, error) {
-	sqlDB, err := sql.Open(sidecar.Repo.Cfg.DB.Type, sidecar.Repo.RepoPath, sidecar.Repo.Cfg.DB.DBInfo)
+	db, err := OpenDB(sidecar.Repo.RepoPath, sidecar.Repo.Cfg.DB)
 	if err != nil {
 		return nil, err
 	}
-	sqlConnector := &SQLConnector{
-		sidecar: sidecar,
-		DB:      &bob.DB{DB: sqlDB.DB},
+	replicas, err := openReplicas(sidecar.Repo.Cfg.DB)
+	if err != nil {
+		return nil, err
 	}
-	sidecar.RegisterLifecycleHook(sqlConnector)
-	return sqlConnector, nil
+	return newSQLConnector(sidecar, db, replicas), nil
 }
 
 func NewSQLConnectorWithDB(sidecar *base.CustomSidecar, db *sqlx.DB) (*SQLConnector, error) {
+	return newSQLConnector(sidecar, &bob.DB{DB: db.DB}, nil), nil
+}
+
+func newSQLConnector(sidecar *base.CustomSidecar, db *bob.DB, replicas []*replica) *SQLConnector {
+	instrument := &queryInstrumenter{
+		metrics:       newQueryMetrics(),
+		slowThreshold: sidecar.Repo.Cfg.DB.SlowQueryThreshold.ToDuration(),
+	}
+	for _, replica := range replicas {
+		replica.executor = instrumentedExecutor{exec: replica.db, name: replica.name, instrument: instrument}
+	}
+	instrumented := newInstrumentedDB(db, primaryDBName, instrument, func() int64 {
+		return int64(sidecar.UUIDGenerator.Generate())
+	})
+	executor := contextExecutor{db: instrumented}
 	sqlConnector := &SQLConnector{
-		sidecar: sidecar,
-		DB:      &bob.DB{DB: db.DB},
+		sidecar:      sidecar,
+		DB:           db,
+		Executor:     executor,
+		ReadExecutor: newReadExecutor(executor, replicas),
+		replicas:     replicas,
+		db:           instrumented,
+		instrument:   instrument,
 	}
 	sidecar.RegisterLifecycleHook(sqlConnector)
-	return sqlConnector, nil
+	return sqlConnector
+}
+
+// OpenDB opens the db of the config with its pool settings without connecting or migrating it
+func OpenDB(repoPath string, cfg config.DB) (*bob.DB, error) {
+	sqlDB, err := sql.Open(cfg.Type, repoPath, cfg.DBInfo)
+	if err != nil {
+		return nil, err
+	}
+	applyPoolConfig(sqlDB.DB, cfg)
+	return &bob.DB{DB: sqlDB.DB}, nil
 }
 
 func (c *SQLConnector) ComponentName() string {
@@ -54,33 +90,36 @@ func (c *SQLConnector) ComponentName() string {
 }
 
 func (c *SQLConnector) Start() error {
-	return build.TryCreateDDLTables(c.sidecar.Ctx, c.DB)
-}
-
-func (c *SQLConnector) Stop() error {
+	cfg := c.sidecar.Repo.Cfg.DB
+	if err := c.waitForDB(c.sidecar.Ctx, cfg.StartupWaitTimeout.ToDuration()); err != nil {
+		return err
+	}
+	migrations, err := LoadBuiltinMigrations(cfg.Type)
+	if err != nil {
+		return err
+	}
+	applied, err := NewMigrator(c.DB, migrations).Up(c.sidecar.Ctx, 0)
+	if err != nil {
+		return err
+	}
+	for _, migration := range applied {
+		log.Info("Applied migration", "version", migration.Version, "name", migration.Name)
+	}
+	// an unreachable replica does not stop the start, the reads go to the primary until it is healthy
+	ctx, cancel := context.WithTimeout(c.sidecar.Ctx, dbWaitMaxDelay)
+	c.pingReplicas(ctx)
+	cancel()
+	if interval := cfg.HealthCheckInterval.ToDuration(); interval > 0 {
+		c.startHealthCheck(interval)
+	}
 	return nil
 }
 
-func (c *SQLConnector) SubmitDBChangesByTransaction(ctx context.Context, dbActions ...DBAction) error {
-	dbTX, err := c.DB.Begin(ctx)
-	if err != nil {
-		return errors.Wrap(err, "failed to begin db transaction")
-	}
-	// The rollback will be ignored if the tx has been committed later in the function.
-	defer func() {
-		if err != nil {
-			if rollbackErr := dbTX.Rollback(ctx); rollbackErr != nil {
-				log.Warn("Failed to rollback", "err", rollbackErr)
-			}
-		}
-	}()
-	for _, dbAction := range dbActions {
-		if err := dbAction(dbTX); err != nil {
-			return err
+func (c *SQLConnector) Stop() error {
+	for _, replica := range c.replicas {
+		if err := replica.db.Close(); err != nil {
+			log.Warn("Failed to close replica", "db", replica.name, "err", err)
 		}
 	}
-	if err := dbTX.Commit(ctx); err != nil {
-		return errors.Wrap(err, "failed to commit db transaction")
-	}
 	return nil
 }
@@ -0,0 +1,156 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stephenafamo/bob"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/config"
+)
+
+const (
+	primaryDBName = "primary"
+
+	dbWaitBaseDelay = 100 * time.Millisecond
+	dbWaitMaxDelay  = 5 * time.Second
+)
+
+// DBStatus is the result of the latest health check and the connection pool statistics
+type DBStatus struct {
+	// Name is primary or the host of the replica
+	Name    string
+	Healthy bool
+	// LastCheckTime is zero before the first check
+	LastCheckTime time.Time
+	LastError     string
+	Stats         sql.DBStats
+	// Replicas is only set for the primary
+	Replicas []DBStatus
+}
+
+type dbHealth struct {
+	lock      sync.RWMutex
+	checkTime time.Time
+	err       error
+}
+
+func applyPoolConfig(sqlDB *sql.DB, cfg config.DB) {
+	if cfg.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime.ToDuration())
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime.ToDuration())
+	}
+}
+
+func (h *dbHealth) ping(ctx context.Context, db *bob.DB, name string) error {
+	err := db.PingContext(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "failed to ping db")
+	}
+	h.record(name, err)
+	return err
+}
+
+// record sets the health and logs its transitions
+func (h *dbHealth) record(name string, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if err != nil {
+		if h.err == nil {
+			log.Warn("Db is unhealthy", "db", name, "err", err)
+		}
+	} else if h.err != nil {
+		log.Info("Db is healthy again", "db", name)
+	}
+	h.checkTime = time.Now()
+	h.err = err
+}
+
+func (h *dbHealth) healthy() bool {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	return !h.checkTime.IsZero() && h.err == nil
+}
+
+func (h *dbHealth) status(db *bob.DB, name string) DBStatus {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	status := DBStatus{
+		Name:          name,
+		Healthy:       !h.checkTime.IsZero() && h.err == nil,
+		LastCheckTime: h.checkTime,
+		Stats:         db.Stats(),
+	}
+	if h.err != nil {
+		status.LastError = h.err.Error()
+	}
+	return status
+}
+
+// Ping checks the primary db and records the result as the health
+func (c *SQLConnector) Ping(ctx context.Context) error {
+	return c.health.ping(ctx, c.DB, primaryDBName)
+}
+
+func (c *SQLConnector) Status() DBStatus {
+	status := c.health.status(c.DB, primaryDBName)
+	for _, replica := range c.replicas {
+		status.Replicas = append(status.Replicas, replica.health.status(replica.db, replica.name))
+	}
+	return status
+}
+
+// waitForDB pings the db with backoff until it is reachable or the timeout
+func (c *SQLConnector) waitForDB(ctx context.Context, timeout time.Duration) error {
+	if timeout <= 0 {
+		return c.Ping(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for attempt := 1; ; attempt++ {
+		err := c.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+		delay := txRetryDelay(attempt, dbWaitBaseDelay, dbWaitMaxDelay)
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) < delay {
+			return errors.Wrap(err, "db is unavailable")
+		}
+		log.Warn("Waiting for db", "attempt", attempt, "retry_after", delay, "err", err)
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(err, "db is unavailable")
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c *SQLConnector) startHealthCheck(interval time.Duration) {
+	c.sidecar.SafeGoPersistentTask(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.sidecar.Ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			ctx, cancel := context.WithTimeout(c.sidecar.Ctx, interval)
+			// the failures have been logged
+			_ = c.Ping(ctx)
+			c.pingReplicas(ctx)
+			cancel()
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-sidecar/db/memory"
+)
+
+func TestSQLConnectorHealth(t *testing.T) {
+	sidecar := base.NewMockCustomSidecar(t)
+	sqlConnector := NewMockSQLConnector(t, sidecar)
+	ctx := sidecar.BackgroundContext().Ctx
+
+	// the startup wait records the first health check
+	status := sqlConnector.Status()
+	require.True(t, status.Healthy)
+	require.WithinDuration(t, time.Now(), status.LastCheckTime, time.Second)
+	require.Empty(t, status.LastError)
+	require.Positive(t, status.Stats.OpenConnections)
+
+	// the unreachable db fails after the timeout
+	closedDB, err := memory.OpenSQLDB()
+	require.Nil(t, err)
+	require.Nil(t, closedDB.Close())
+	closedConnector, err := NewSQLConnectorWithDB(sidecar, closedDB)
+	require.Nil(t, err)
+	start := time.Now()
+	require.NotNil(t, closedConnector.waitForDB(ctx, 300*time.Millisecond))
+	require.Less(t, time.Since(start), time.Second)
+	status = closedConnector.Status()
+	require.False(t, status.Healthy)
+	require.NotEmpty(t, status.LastError)
+}
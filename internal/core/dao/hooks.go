@@ -0,0 +1,156 @@
+package dao
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+	"github.com/stephenafamo/bob/orm"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+)
+
+// idGenerator is implemented by the executors of the sql connector, they generate the ids of the inserted rows
+// from the snowflake node of the connector's sidecar, so the connectors of different nodes never share one
+type idGenerator interface {
+	nextID() (int64, error)
+}
+
+// nextID generates an id by the executor, the executors not created by a sql connector, e.g. SQLConnector.DB, can not insert
+func nextID(exec bob.Executor) (int64, error) {
+	generator, ok := exec.(idGenerator)
+	if !ok {
+		return 0, errors.Errorf("executor %T does not generate ids, use the executors of the sql connector", exec)
+	}
+	return generator.nextID()
+}
+
+func init() {
+	registerTableHooks(model.APIKeys)
+	registerTableHooks(model.AuditLogs)
+	registerTableHooks(model.LoginAttempts)
+	registerTableHooks(model.OauthStates)
+	registerTableHooks(model.Permissions)
+	registerTableHooks(model.RecoveryCodes)
+	registerTableHooks(model.RefreshTokens)
+	registerTableHooks(model.RolePermissions)
+	registerTableHooks(model.Users)
+	registerTableHooks(model.UserAuths)
+	registerTableHooks(model.VerificationCodes)
+}
+
+// registerTableHooks fills the columns every table has, so the callers only set their own columns:
+// the insert gets a snowflake id from the executor, utc create/update time and zero values for the unset columns,
+// the update stores the set times in utc, sets the utc update time and increases the version unless the query sets them itself.
+// the update uses the query hook because BeforeUpdateHooks only run for the updates of loaded models
+func registerTableHooks[T any, Tslice ~[]T, Tset orm.Setter[T, *dialect.InsertQuery, *dialect.UpdateQuery]](table *psql.Table[T, Tslice, Tset]) {
+	table.BeforeInsertHooks.AppendHooks(beforeInsertHook[Tset])
+	table.UpdateQueryHooks.AppendHooks(beforeUpdateQueryHook)
+}
+
+func beforeInsertHook[Tset any](ctx context.Context, exec bob.Executor, setter Tset) (context.Context, error) {
+	setterValue := reflect.ValueOf(setter)
+	if setterValue.Kind() != reflect.Pointer || setterValue.Elem().Kind() != reflect.Struct {
+		return ctx, errors.Errorf("unsupported setter type %T", setter)
+	}
+	setterValue = setterValue.Elem()
+	now := time.Now().UTC()
+	for i := 0; i < setterValue.NumField(); i++ {
+		field := setterValue.Field(i)
+		if field.Kind() != reflect.Pointer || !field.CanSet() {
+			continue
+		}
+		if !field.IsNil() {
+			if t, ok := field.Interface().(*time.Time); ok {
+				field.Set(reflect.ValueOf(lo.ToPtr(t.UTC())))
+			}
+			continue
+		}
+		switch setterValue.Type().Field(i).Name {
+		case "ID":
+			id, err := nextID(exec)
+			if err != nil {
+				return ctx, err
+			}
+			field.Set(reflect.ValueOf(lo.ToPtr(id)))
+		case "CreateTime", "UpdateTime":
+			field.Set(reflect.ValueOf(lo.ToPtr(now)))
+		default:
+			// the column defaults are all zero values, and sqlite does not support DEFAULT in values
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+	}
+	return ctx, nil
+}
+
+func beforeUpdateQueryHook(ctx context.Context, _ bob.Executor, q *dialect.UpdateQuery) (context.Context, error) {
+	columns, err := setColumns(ctx, q)
+	if err != nil {
+		return ctx, err
+	}
+	for i, set := range q.Set.Set {
+		if set, ok := set.(bob.Expression); ok {
+			q.Set.Set[i] = utcArgs{set}
+		}
+	}
+	if !columns[updateTimeColumn] {
+		um.SetCol(updateTimeColumn).ToArg(time.Now().UTC()).Apply(q)
+	}
+	if !columns[versionColumn] {
+		um.SetCol(versionColumn).To(psql.Quote(versionColumn).OP("+", psql.Arg(1))).Apply(q)
+	}
+	return ctx, nil
+}
+
+// utcArgs writes the expression with its time args converted to utc,
+// the timestamp columns keep the wall clock of the arg, so a local time would be stored as the wrong instant
+type utcArgs struct {
+	bob.Expression
+}
+
+func (e utcArgs) WriteSQL(ctx context.Context, w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	args, err := e.Expression.WriteSQL(ctx, w, d, start)
+	if err != nil {
+		return nil, err
+	}
+	// copied, the args may be the values held by the expression
+	args = slices.Clone(args)
+	for i, arg := range args {
+		switch t := arg.(type) {
+		case time.Time:
+			args[i] = t.UTC()
+		case *time.Time:
+			if t != nil {
+				args[i] = lo.ToPtr(t.UTC())
+			}
+		}
+	}
+	return args, nil
+}
+
+// setColumns returns the columns set by the update query, e.g. `"version" = $1` or `"user"."version" = ("version" + $1)`
+func setColumns(ctx context.Context, q *dialect.UpdateQuery) (map[string]bool, error) {
+	columns := make(map[string]bool, len(q.Set.Set))
+	for _, set := range q.Set.Set {
+		var buf strings.Builder
+		if _, err := bob.Express(ctx, &buf, dialect.Dialect, 1, set); err != nil {
+			return nil, errors.Wrap(err, "failed to build set clause")
+		}
+		column, _, _ := strings.Cut(buf.String(), "=")
+		column = strings.Trim(strings.TrimSpace(column), `()`)
+		if i := strings.LastIndex(column, "."); i >= 0 {
+			column = column[i+1:]
+		}
+		columns[strings.Trim(column, `"`)] = true
+	}
+	return columns, nil
+}
@@ -0,0 +1,160 @@
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+)
+
+func TestModelHooks(t *testing.T) {
+	sidecar := base.NewMockCustomSidecar(t)
+	sqlConnector := NewMockSQLConnector(t, sidecar)
+	ctx := sidecar.BackgroundContext().Ctx
+
+	// the insert only sets its own columns
+	user, err := model.Users.Insert(&model.UserSetter{
+		Nickname: lo.ToPtr("alice"),
+	}).One(ctx, sqlConnector.Executor)
+	require.Nil(t, err)
+	require.NotZero(t, user.ID)
+	require.WithinDuration(t, time.Now(), user.CreateTime, time.Second)
+	require.Equal(t, user.CreateTime, user.UpdateTime)
+	require.True(t, user.DeleteTime.IsZero())
+	require.Equal(t, entity.DelStateActive, user.DelState)
+	require.Zero(t, user.Version)
+	require.Equal(t, "alice", user.Nickname)
+	require.Empty(t, user.Role)
+	other, err := model.Users.Insert(&model.UserSetter{}).One(ctx, sqlConnector.Executor)
+	require.Nil(t, err)
+	require.NotEqual(t, user.ID, other.ID)
+
+	// the insert keeps the set columns and stores the times in utc
+	createTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+8", 8*60*60))
+	user, err = model.Users.Insert(&model.UserSetter{
+		ID:         lo.ToPtr(int64(1)),
+		CreateTime: lo.ToPtr(createTime),
+		Version:    lo.ToPtr(int64(3)),
+	}).One(ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), user.ID)
+	require.Equal(t, createTime.UTC(), user.CreateTime.UTC())
+	require.Equal(t, int64(3), user.Version)
+
+	// the update increases the version unless it is set
+	time.Sleep(10 * time.Millisecond)
+	_, err = model.Users.Update(
+		model.UserSetter{Nickname: lo.ToPtr("bob")}.UpdateMod(),
+		model.UpdateWhere.Users.ID.EQ(1),
+	).Exec(ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	updated, err := model.FindUser(ctx, sqlConnector.DB, 1)
+	require.Nil(t, err)
+	require.Equal(t, int64(4), updated.Version)
+	require.True(t, updated.UpdateTime.After(user.UpdateTime))
+
+	_, err = model.Users.Update(
+		model.UserSetter{Version: lo.ToPtr(int64(10))}.UpdateMod(),
+		model.UpdateWhere.Users.ID.EQ(1),
+	).Exec(ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	updated, err = model.FindUser(ctx, sqlConnector.DB, 1)
+	require.Nil(t, err)
+	require.Equal(t, int64(10), updated.Version)
+
+	_, err = model.Users.Update(
+		model.UserSetter{Nickname: lo.ToPtr("carol")}.UpdateMod(),
+		model.UpdateWhere.Users.ID.EQ(1),
+	).Exec(bob.SkipQueryHooks(ctx), sqlConnector.DB)
+	require.Nil(t, err)
+	updated, err = model.FindUser(ctx, sqlConnector.DB, 1)
+	require.Nil(t, err)
+	require.Equal(t, int64(10), updated.Version)
+}
+
+func TestModelHooks_LocalTimeZone(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC+8", 8*60*60)
+	t.Cleanup(func() {
+		time.Local = local
+	})
+
+	sidecar := base.NewMockCustomSidecar(t)
+	sqlConnector := NewMockSQLConnector(t, sidecar)
+	ctx := sidecar.BackgroundContext().Ctx
+
+	user, err := model.Users.Insert(&model.UserSetter{}).One(ctx, sqlConnector.Executor)
+	require.Nil(t, err)
+
+	// the times set by the update are stored in utc like the inserted ones
+	deleteTime := time.Now().Truncate(time.Second)
+	require.NotEqual(t, time.UTC, deleteTime.Location())
+	_, err = model.Users.Update(
+		model.UserSetter{DeleteTime: lo.ToPtr(deleteTime)}.UpdateMod(),
+		model.UpdateWhere.Users.ID.EQ(user.ID),
+	).Exec(ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	updated, err := model.FindUser(ctx, sqlConnector.DB, user.ID)
+	require.Nil(t, err)
+	require.Equal(t, deleteTime.UTC(), updated.DeleteTime.UTC())
+
+	// so they match the where clauses built from utc times
+	count, err := model.Users.Query(
+		model.SelectWhere.Users.DeleteTime.EQ(deleteTime.UTC()),
+	).Count(ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), count)
+	count, err = model.Users.Query(
+		model.SelectWhere.Users.UpdateTime.GT(deleteTime.UTC().Add(-time.Minute)),
+	).Count(ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func TestModelHooks_IDGenerator(t *testing.T) {
+	sidecar := base.NewMockCustomSidecar(t)
+	sqlConnector := NewMockSQLConnector(t, sidecar)
+	ctx := sidecar.BackgroundContext().Ctx
+
+	// the ids come from the executor, not from the last constructed connector
+	var lastID int64
+	instrumented := newInstrumentedDB(sqlConnector.DB, primaryDBName, sqlConnector.instrument, func() int64 {
+		lastID++
+		return lastID
+	})
+	executor := contextExecutor{db: instrumented}
+	user, err := model.Users.Insert(&model.UserSetter{}).One(ctx, executor)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), user.ID)
+	err = submitDBChangesByTransaction(ctx, instrumented, nil, func(ctx context.Context, dbTX bob.Transaction) error {
+		user, err := model.Users.Insert(&model.UserSetter{}).One(ctx, dbTX)
+		if err != nil {
+			return err
+		}
+		require.Equal(t, int64(2), user.ID)
+		// the nested transaction is a savepoint
+		return submitDBChangesByTransaction(ctx, instrumented, nil, func(ctx context.Context, dbTX bob.Transaction) error {
+			user, err := model.Users.Insert(&model.UserSetter{}).One(ctx, dbTX)
+			if err != nil {
+				return err
+			}
+			require.Equal(t, int64(3), user.ID)
+			return nil
+		})
+	})
+	require.Nil(t, err)
+	user, err = model.Users.Insert(&model.UserSetter{}).One(ctx, sqlConnector.Executor)
+	require.Nil(t, err)
+	require.Greater(t, user.ID, int64(3))
+
+	// the executors not created by the connector can not generate the ids
+	_, err = model.Users.Insert(&model.UserSetter{}).One(ctx, sqlConnector.DB)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,270 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// the upper bounds of the query latency histogram, the queries slower than the last one are only in the count
+var queryLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+type reqIDContextKey struct{}
+
+type queryNameContextKey struct{}
+
+// WithReqID carries the request id of reqctx.ReqCtx to the queries, so the slow query logs can be matched to the request
+func WithReqID(ctx context.Context, reqID int64) context.Context {
+	return context.WithValue(ctx, reqIDContextKey{}, reqID)
+}
+
+// WithQueryName names the queries run with the context in the metrics and the slow query logs,
+// the name is `<statement> <table>` by default, e.g. `select user`
+func WithQueryName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, queryNameContextKey{}, name)
+}
+
+// QueryStats are the metrics of the queries of the same name
+type QueryStats struct {
+	Name          string
+	Count         int64
+	ErrorCount    int64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	// Buckets are the cumulative counts of the queries not slower than the upper bounds
+	Buckets []QueryLatencyBucket
+}
+
+type QueryLatencyBucket struct {
+	UpperBound time.Duration
+	Count      int64
+}
+
+// queryMetrics records the count and the latency of the queries by name
+type queryMetrics struct {
+	lock  sync.Mutex
+	stats map[string]*queryStats
+}
+
+type queryStats struct {
+	count         int64
+	errorCount    int64
+	totalDuration time.Duration
+	maxDuration   time.Duration
+	// not cumulative, the last one counts the queries slower than all the bounds
+	buckets []int64
+}
+
+func newQueryMetrics() *queryMetrics {
+	return &queryMetrics{
+		stats: make(map[string]*queryStats),
+	}
+}
+
+func (m *queryMetrics) observe(name string, duration time.Duration, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	stats, ok := m.stats[name]
+	if !ok {
+		stats = &queryStats{buckets: make([]int64, len(queryLatencyBuckets)+1)}
+		m.stats[name] = stats
+	}
+	stats.count++
+	if err != nil {
+		stats.errorCount++
+	}
+	stats.totalDuration += duration
+	stats.maxDuration = max(stats.maxDuration, duration)
+	stats.buckets[sort.Search(len(queryLatencyBuckets), func(i int) bool {
+		return duration <= queryLatencyBuckets[i]
+	})]++
+}
+
+// snapshot returns the stats ordered by name
+func (m *queryMetrics) snapshot() []QueryStats {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	res := make([]QueryStats, 0, len(m.stats))
+	for name, stats := range m.stats {
+		item := QueryStats{
+			Name:          name,
+			Count:         stats.count,
+			ErrorCount:    stats.errorCount,
+			TotalDuration: stats.totalDuration,
+			MaxDuration:   stats.maxDuration,
+			Buckets:       make([]QueryLatencyBucket, len(queryLatencyBuckets)),
+		}
+		var cumulative int64
+		for i, upperBound := range queryLatencyBuckets {
+			cumulative += stats.buckets[i]
+			item.Buckets[i] = QueryLatencyBucket{UpperBound: upperBound, Count: cumulative}
+		}
+		res = append(res, item)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// queryInstrumenter measures every query, records the metrics and logs the slow ones
+type queryInstrumenter struct {
+	metrics *queryMetrics
+	// 0 disables the slow query logs
+	slowThreshold time.Duration
+}
+
+func (i *queryInstrumenter) observe(ctx context.Context, db string, query string, args []any, start time.Time, err error) {
+	duration := time.Since(start)
+	name, ok := ctx.Value(queryNameContextKey{}).(string)
+	if !ok {
+		name = queryName(query)
+	}
+	i.metrics.observe(name, duration, err)
+	if i.slowThreshold <= 0 || duration < i.slowThreshold {
+		return
+	}
+	fields := []any{"db", db, "name", name, "duration", duration, "query", query, "args", redactArgs(args)}
+	if reqID, ok := ctx.Value(reqIDContextKey{}).(int64); ok {
+		fields = append(fields, "req_id", reqID)
+	}
+	if err != nil {
+		fields = append(fields, "err", err)
+	}
+	log.Warn("Slow query", fields...)
+}
+
+// queryName returns `<statement> <table>` of the query built by bob, or only the statement if it has no table,
+// so the metrics are kept per table instead of per query text
+func queryName(query string) string {
+	tokens := strings.Fields(query)
+	if len(tokens) == 0 {
+		return "unknown"
+	}
+	statement := strings.ToLower(tokens[0])
+	var tableKeyword string
+	switch statement {
+	case "select", "delete":
+		tableKeyword = "from"
+	case "insert":
+		tableKeyword = "into"
+	case "update":
+		tableKeyword = "update"
+	default:
+		return statement
+	}
+	for i, token := range tokens[:len(tokens)-1] {
+		if strings.EqualFold(token, tableKeyword) {
+			table := tokens[i+1]
+			if j := strings.LastIndex(table, "."); j >= 0 {
+				table = table[j+1:]
+			}
+			return statement + " " + strings.Trim(table, `"()`)
+		}
+	}
+	return statement
+}
+
+// redactArgs only keeps the types of the args, they may be passwords, tokens or personal data
+func redactArgs(args []any) []string {
+	res := make([]string, len(args))
+	for i, arg := range args {
+		res[i] = fmt.Sprintf("%T", arg)
+	}
+	return res
+}
+
+// instrumentedExecutor measures the queries of the executor, name is the db they run on for the logs
+type instrumentedExecutor struct {
+	exec       bob.Executor
+	name       string
+	instrument *queryInstrumenter
+}
+
+func (e instrumentedExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	start := time.Now()
+	rows, err := e.exec.QueryContext(ctx, query, args...)
+	e.instrument.observe(ctx, e.name, query, args, start, err)
+	return rows, err
+}
+
+func (e instrumentedExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := e.exec.ExecContext(ctx, query, args...)
+	e.instrument.observe(ctx, e.name, query, args, start, err)
+	return result, err
+}
+
+// instrumentedDB measures the queries of the db and of the transactions it begins,
+// it and its transactions generate the ids of the inserted rows by generateID
+type instrumentedDB struct {
+	instrumentedExecutor
+	db         *bob.DB
+	generateID func() int64
+}
+
+func newInstrumentedDB(db *bob.DB, name string, instrument *queryInstrumenter, generateID func() int64) instrumentedDB {
+	return instrumentedDB{
+		instrumentedExecutor: instrumentedExecutor{exec: db, name: name, instrument: instrument},
+		db:                   db,
+		generateID:           generateID,
+	}
+}
+
+func (d instrumentedDB) nextID() (int64, error) {
+	return d.generateID(), nil
+}
+
+func (d instrumentedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (bob.Transaction, error) {
+	tx, err := d.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return instrumentedTx{
+		Transaction:          tx,
+		instrumentedExecutor: instrumentedExecutor{exec: tx, name: d.name, instrument: d.instrument},
+		generateID:           d.generateID,
+	}, nil
+}
+
+type instrumentedTx struct {
+	bob.Transaction
+	instrumentedExecutor
+	generateID func() int64
+}
+
+func (t instrumentedTx) nextID() (int64, error) {
+	return t.generateID(), nil
+}
+
+func (t instrumentedTx) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	return t.instrumentedExecutor.QueryContext(ctx, query, args...)
+}
+
+func (t instrumentedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.instrumentedExecutor.ExecContext(ctx, query, args...)
+}
+
+// QueryMetrics returns the count and the latency of the queries run by the executors of the connector, ordered by name
+func (c *SQLConnector) QueryMetrics() []QueryStats {
+	return c.instrument.metrics.snapshot()
+}
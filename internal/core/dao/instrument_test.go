@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+)
+
+func TestQueryMetrics(t *testing.T) {
+	sidecar := base.NewMockCustomSidecar(t)
+	sqlConnector := NewMockSQLConnector(t, sidecar)
+	ctx := sidecar.BackgroundContext().Ctx
+	// only count the queries of the test
+	sqlConnector.instrument.metrics = newQueryMetrics()
+
+	// the queries of the transactions, the executors and the failed ones are all measured
+	require.Nil(t, sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		_, err := model.Users.Insert(&model.UserSetter{ID: lo.ToPtr(int64(1))}).Exec(ctx, dbTX)
+		return err
+	}))
+	for range 2 {
+		_, err := model.FindUser(ctx, sqlConnector.Executor, 1)
+		require.Nil(t, err)
+	}
+	_, err := model.FindUser(WithQueryName(ctx, "find user"), sqlConnector.ReadExecutor, 1)
+	require.Nil(t, err)
+	_, err = sqlConnector.Executor.ExecContext(ctx, `delete from "missing"`)
+	require.NotNil(t, err)
+
+	metrics := sqlConnector.QueryMetrics()
+	require.Equal(t, []string{"delete missing", "find user", "insert user", "select user"}, lo.Map(metrics, func(item QueryStats, _ int) string {
+		return item.Name
+	}))
+	require.Equal(t, []int64{1, 1, 1, 2}, lo.Map(metrics, func(item QueryStats, _ int) int64 {
+		return item.Count
+	}))
+	require.Equal(t, []int64{1, 0, 0, 0}, lo.Map(metrics, func(item QueryStats, _ int) int64 {
+		return item.ErrorCount
+	}))
+	selectStats := metrics[3]
+	require.Positive(t, selectStats.TotalDuration)
+	require.LessOrEqual(t, selectStats.MaxDuration, selectStats.TotalDuration)
+	require.Len(t, selectStats.Buckets, len(queryLatencyBuckets))
+	for i := 1; i < len(selectStats.Buckets); i++ {
+		require.GreaterOrEqual(t, selectStats.Buckets[i].Count, selectStats.Buckets[i-1].Count)
+	}
+	require.Equal(t, selectStats.Count, selectStats.Buckets[len(selectStats.Buckets)-1].Count)
+}
+
+func TestQueryName(t *testing.T) {
+	require.Equal(t, "select user", queryName(`SELECT "user"."id" AS "id" FROM "user" AS "user" WHERE ("user"."id" = $1)`))
+	require.Equal(t, "insert refresh_token", queryName(`INSERT INTO "refresh_token" AS "refresh_token" ("id") VALUES ($1)`))
+	require.Equal(t, "update user", queryName(`UPDATE "public"."user" SET "version" = $1`))
+	require.Equal(t, "delete audit_log", queryName(`DELETE FROM audit_log WHERE id < $1`))
+	require.Equal(t, "savepoint", queryName("savepoint sp_1"))
+	require.Equal(t, "unknown", queryName(" "))
+	require.Equal(t, []string{"string", "int64", "<nil>"}, redactArgs([]any{"secret", int64(1), nil}))
+}
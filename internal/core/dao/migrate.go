@@ -0,0 +1,396 @@
+package dao
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+
+	"github.com/zunkk/go-project-startup/build"
+	"github.com/zunkk/go-sidecar/db"
+)
+
+const (
+	migrationTable     = "schema_migrations"
+	migrationLockTable = "schema_migrations_lock"
+)
+
+// Dialects are the db types with built-in migrations, a migration is written for all of them with the same version
+var Dialects = []db.Type{db.DBTypeSqlite, db.DBTypePostgres}
+
+var (
+	// migrationFileRegexp matches <version>_<name>.<up|down>.sql
+	migrationFileRegexp = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	migrationNameRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+// Migration is a versioned schema change, a migration with an empty down sql is irreversible
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+	// Checksum is the sha256 of the up sql, an applied migration must not be edited
+	Checksum string
+}
+
+type MigrationState string
+
+const (
+	MigrationStatePending MigrationState = "pending"
+	MigrationStateApplied MigrationState = "applied"
+	// MigrationStateModified means the up sql has been edited after it was applied
+	MigrationStateModified MigrationState = "modified"
+	// MigrationStateMissing means the migration was applied by another binary and is unknown to this one
+	MigrationStateMissing MigrationState = "missing"
+)
+
+type MigrationStatus struct {
+	Version int64
+	Name    string
+	State   MigrationState
+	// zero if pending
+	ApplyTime time.Time
+}
+
+type appliedMigration struct {
+	version   int64
+	name      string
+	checksum  string
+	applyTime time.Time
+}
+
+// LoadBuiltinMigrations loads the embedded migrations of the db type
+func LoadBuiltinMigrations(dbType db.Type) ([]*Migration, error) {
+	if !lo.Contains(Dialects, dbType) {
+		return nil, errors.Errorf("unsupported db type %s", dbType)
+	}
+	return LoadMigrations(build.Migrations, path.Join(build.MigrationsDir, string(dbType)))
+}
+
+// LoadMigrations reads the migration files in the dir of fsys ordered by version
+func LoadMigrations(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read migration dir")
+	}
+	migrationMap := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matches := migrationFileRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, errors.Errorf("invalid migration file name %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid migration version %s", entry.Name())
+		}
+		migration, ok := migrationMap[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			migrationMap[version] = migration
+		} else if migration.Name != matches[2] {
+			return nil, errors.Errorf("migration version %d is used by both %s and %s", version, migration.Name, matches[2])
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read migration file %s", entry.Name())
+		}
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(migrationMap))
+	for _, migration := range migrationMap {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, errors.Errorf("migration %s has no up sql", migration)
+		}
+		checksum := sha256.Sum256([]byte(migration.Up))
+		migration.Checksum = hex.EncodeToString(checksum[:])
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// CreateMigration writes an up and a down file with the next version to the sub dir of every dialect in the source dir,
+// returns the file paths
+func CreateMigration(dir string, name string) ([]string, error) {
+	if !migrationNameRegexp.MatchString(name) {
+		return nil, errors.Errorf("invalid migration name %s, only lowercase letters, digits and underscores are allowed", name)
+	}
+	var version int64 = 1
+	for _, dialect := range Dialects {
+		migrations, err := LoadMigrations(os.DirFS(dir), string(dialect))
+		if err != nil {
+			return nil, err
+		}
+		if len(migrations) != 0 {
+			version = max(version, migrations[len(migrations)-1].Version+1)
+		}
+	}
+	var files []string
+	for _, dialect := range Dialects {
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(dir, string(dialect), fmt.Sprintf("%06d_%s.%s.sql", version, name, direction))
+			if err := os.WriteFile(file, []byte("-- "+name+"\n"), 0o644); err != nil {
+				return nil, errors.Wrapf(err, "failed to write migration file %s", file)
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+func (m *Migration) String() string {
+	return fmt.Sprintf("%06d_%s", m.Version, m.Name)
+}
+
+// Migrator applies the migrations, every run holds the row lock of schema_migrations_lock in one transaction,
+// so the nodes starting together run the migrations one by one and a failed run leaves no partial changes
+type Migrator struct {
+	db         *bob.DB
+	migrations []*Migration
+}
+
+func NewMigrator(db *bob.DB, migrations []*Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+}
+
+// Up applies at most steps pending migrations in version order, all of them if steps is 0
+func (m *Migrator) Up(ctx context.Context, steps int) ([]*Migration, error) {
+	var res []*Migration
+	err := m.withLock(ctx, func(dbTX bob.Transaction, applied map[int64]*appliedMigration) error {
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if steps > 0 && len(res) == steps {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if _, err := dbTX.ExecContext(ctx, migration.Up); err != nil {
+				return errors.Wrapf(err, "failed to apply migration %s", migration)
+			}
+			if _, err := dbTX.ExecContext(ctx,
+				`insert into "`+migrationTable+`" ("version", "name", "checksum", "apply_time") values ($1, $2, $3, $4)`,
+				migration.Version, migration.Name, migration.Checksum, time.Now().UTC(),
+			); err != nil {
+				return errors.Wrapf(err, "failed to record migration %s", migration)
+			}
+			res = append(res, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Down rolls back the latest steps applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps <= 0 {
+		return nil, errors.New("steps must be positive")
+	}
+	var res []*Migration
+	err := m.withLock(ctx, func(dbTX bob.Transaction, applied map[int64]*appliedMigration) error {
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(res) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if strings.TrimSpace(migration.Down) == "" {
+				return errors.Errorf("migration %s is irreversible", migration)
+			}
+			if _, err := dbTX.ExecContext(ctx, migration.Down); err != nil {
+				return errors.Wrapf(err, "failed to roll back migration %s", migration)
+			}
+			if _, err := dbTX.ExecContext(ctx, `delete from "`+migrationTable+`" where "version" = $1`, migration.Version); err != nil {
+				return errors.Wrapf(err, "failed to delete migration record %s", migration)
+			}
+			res = append(res, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Status returns the state of the known and the applied migrations ordered by version
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := queryAppliedMigrations(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	var res []MigrationStatus
+	for _, migration := range m.migrations {
+		status := MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+			State:   MigrationStatePending,
+		}
+		if record, ok := applied[migration.Version]; ok {
+			status.State = MigrationStateApplied
+			if record.checksum != migration.Checksum {
+				status.State = MigrationStateModified
+			}
+			status.ApplyTime = record.applyTime
+			delete(applied, migration.Version)
+		}
+		res = append(res, status)
+	}
+	for _, record := range applied {
+		res = append(res, MigrationStatus{
+			Version:   record.version,
+			Name:      record.name,
+			State:     MigrationStateMissing,
+			ApplyTime: record.applyTime,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+	return res, nil
+}
+
+// verify rejects the db changed by an edited migration or by a newer binary
+func (m *Migrator) verify(applied map[int64]*appliedMigration) error {
+	known := make(map[int64]*Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+	for version, record := range applied {
+		migration, ok := known[version]
+		if !ok {
+			return errors.Errorf("applied migration %06d_%s is unknown to this binary", version, record.name)
+		}
+		if record.checksum != migration.Checksum {
+			return errors.Errorf("migration %s has been modified after it was applied", migration)
+		}
+	}
+	return nil
+}
+
+// withLock runs fn in a transaction holding the row lock of schema_migrations_lock (sqlite locks the whole db instead),
+// the other runs block until the transaction ends
+func (m *Migrator) withLock(ctx context.Context, fn func(dbTX bob.Transaction, applied map[int64]*appliedMigration) error) error {
+	if err := m.ensureTables(ctx); err != nil {
+		// nodes starting together race on creating the tables, postgres fails the loser with a duplicate error
+		// instead of skipping the table, the retry sees the table created by the winner
+		if !isDuplicateObjectError(err) {
+			return err
+		}
+		if err := m.ensureTables(ctx); err != nil {
+			return err
+		}
+	}
+	return submitDBChangesByTransaction(ctx, m.db, nil, func(ctx context.Context, dbTX bob.Transaction) error {
+		if _, err := dbTX.ExecContext(ctx, `update "`+migrationLockTable+`" set "lock_time" = $1 where "id" = 1`, time.Now().UTC()); err != nil {
+			return errors.Wrap(err, "failed to lock migrations")
+		}
+		applied, err := queryAppliedMigrations(ctx, dbTX)
+		if err != nil {
+			return err
+		}
+		return fn(dbTX, applied)
+	})
+}
+
+// ensureTables creates the tracking tables, they are not migrations themselves
+func (m *Migrator) ensureTables(ctx context.Context) error {
+	statements := []string{
+		`create table if not exists "` + migrationTable + `"
+(
+    "version"    bigint       not null
+        constraint schema_migrations_pk
+            primary key,
+    "name"       varchar(255) not null default '',
+    "checksum"   varchar(64)  not null default '',
+    "apply_time" timestamp    not null
+)`,
+		`create table if not exists "` + migrationLockTable + `"
+(
+    "id"        bigint    not null
+        constraint schema_migrations_lock_pk
+            primary key,
+    "lock_time" timestamp not null
+)`,
+	}
+	for _, statement := range statements {
+		if _, err := m.db.ExecContext(ctx, statement); err != nil {
+			return errors.Wrap(err, "failed to create migration tables")
+		}
+	}
+	if _, err := m.db.ExecContext(ctx,
+		`insert into "`+migrationLockTable+`" ("id", "lock_time") values (1, $1) on conflict do nothing`,
+		time.Now().UTC(),
+	); err != nil {
+		return errors.Wrap(err, "failed to init migration lock")
+	}
+	return nil
+}
+
+// isDuplicateObjectError reports the duplicate_table error and the unique violation on pg_type
+// raised by concurrent create table if not exists
+func isDuplicateObjectError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == "42P07" || pqErr.Code == "23505"
+}
+
+func queryAppliedMigrations(ctx context.Context, exec bob.Executor) (map[int64]*appliedMigration, error) {
+	rows, err := exec.QueryContext(ctx, `select "version", "name", "checksum", "apply_time" from "`+migrationTable+`"`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query applied migrations")
+	}
+	defer rows.Close()
+	res := map[int64]*appliedMigration{}
+	for rows.Next() {
+		record := &appliedMigration{}
+		if err := rows.Scan(&record.version, &record.name, &record.checksum, &record.applyTime); err != nil {
+			return nil, errors.Wrap(err, "failed to scan applied migration")
+		}
+		res[record.version] = record
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to query applied migrations")
+	}
+	return res, nil
+}
@@ -0,0 +1,218 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-sidecar/db"
+	"github.com/zunkk/go-sidecar/db/memory"
+)
+
+func prepareMigrationDB(t *testing.T) *bob.DB {
+	memoryDB, err := memory.OpenSQLDB()
+	require.Nil(t, err)
+	return &bob.DB{DB: memoryDB.DB}
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	bobDB := prepareMigrationDB(t)
+	fsys := fstest.MapFS{
+		"m/000001_create_a.up.sql":     {Data: []byte(`create table "a" ("id" bigint not null primary key);`)},
+		"m/000001_create_a.down.sql":   {Data: []byte(`drop table "a";`)},
+		"m/000002_add_a_name.up.sql":   {Data: []byte(`alter table "a" add column "name" varchar(64) not null default '';`)},
+		"m/000002_add_a_name.down.sql": {Data: []byte(`alter table "a" drop column "name";`)},
+		"m/000003_seed_a.up.sql":       {Data: []byte(`insert into "a" ("id", "name") values (1, 'x');`)},
+	}
+	migrations, err := LoadMigrations(fsys, "m")
+	require.Nil(t, err)
+	require.Len(t, migrations, 3)
+	require.Equal(t, "000002_add_a_name", migrations[1].String())
+
+	migrator := NewMigrator(bobDB, migrations)
+	applied, err := migrator.Up(ctx, 2)
+	require.Nil(t, err)
+	require.Len(t, applied, 2)
+	applied, err = migrator.Up(ctx, 0)
+	require.Nil(t, err)
+	require.Len(t, applied, 1)
+	applied, err = migrator.Up(ctx, 0)
+	require.Nil(t, err)
+	require.Empty(t, applied)
+
+	// 000003 has no down sql
+	_, err = migrator.Down(ctx, 1)
+	require.NotNil(t, err)
+	_, err = bobDB.ExecContext(ctx, `delete from "a"`)
+	require.Nil(t, err)
+	_, err = bobDB.ExecContext(ctx, `delete from "schema_migrations" where "version" = 3`)
+	require.Nil(t, err)
+	rolledBack, err := NewMigrator(bobDB, migrations[:2]).Down(ctx, 1)
+	require.Nil(t, err)
+	require.Len(t, rolledBack, 1)
+	require.Equal(t, int64(2), rolledBack[0].Version)
+
+	statuses, err := migrator.Status(ctx)
+	require.Nil(t, err)
+	require.Len(t, statuses, 3)
+	require.Equal(t, MigrationStateApplied, statuses[0].State)
+	require.False(t, statuses[0].ApplyTime.IsZero())
+	require.Equal(t, MigrationStatePending, statuses[1].State)
+	require.Equal(t, MigrationStatePending, statuses[2].State)
+
+	// an edited migration and a migration of a newer binary stop the run
+	fsys["m/000001_create_a.up.sql"] = &fstest.MapFile{Data: []byte(`create table "a" ("id" bigint not null);`)}
+	edited, err := LoadMigrations(fsys, "m")
+	require.Nil(t, err)
+	_, err = NewMigrator(bobDB, edited).Up(ctx, 0)
+	require.ErrorContains(t, err, "modified")
+	statuses, err = NewMigrator(bobDB, edited).Status(ctx)
+	require.Nil(t, err)
+	require.Equal(t, MigrationStateModified, statuses[0].State)
+	statuses, err = NewMigrator(bobDB, nil).Status(ctx)
+	require.Nil(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, MigrationStateMissing, statuses[0].State)
+	_, err = NewMigrator(bobDB, nil).Up(ctx, 0)
+	require.ErrorContains(t, err, "unknown")
+
+	// a failed migration leaves no partial changes
+	broken := append(migrations[:1:1], &Migration{Version: 2, Name: "broken", Up: `alter table "a" add column "b" bigint; select * from "nonexistent";`, Checksum: "x"})
+	_, err = NewMigrator(bobDB, broken).Up(ctx, 0)
+	require.NotNil(t, err)
+	_, err = bobDB.ExecContext(ctx, `insert into "a" ("id", "b") values (1, 1)`)
+	require.NotNil(t, err)
+	statuses, err = NewMigrator(bobDB, broken).Status(ctx)
+	require.Nil(t, err)
+	require.Equal(t, MigrationStatePending, statuses[1].State)
+}
+
+func TestIsDuplicateObjectError(t *testing.T) {
+	require.True(t, isDuplicateObjectError(errors.Wrap(&pq.Error{Code: "42P07"}, "failed to create migration tables")))
+	require.True(t, isDuplicateObjectError(errors.Wrap(&pq.Error{Code: "23505"}, "failed to create migration tables")))
+	require.False(t, isDuplicateObjectError(errors.Wrap(&pq.Error{Code: "42601"}, "failed to create migration tables")))
+	require.False(t, isDuplicateObjectError(errors.New("failed to create migration tables")))
+}
+
+func TestMigrator_BuiltinMigrations(t *testing.T) {
+	ctx := context.Background()
+	migrations, err := LoadBuiltinMigrations(db.DBTypeSqlite)
+	require.Nil(t, err)
+	require.NotEmpty(t, migrations)
+	// every dialect has the same versions
+	for _, dialect := range Dialects {
+		dialectMigrations, err := LoadBuiltinMigrations(dialect)
+		require.Nil(t, err)
+		require.Equal(t, lo.Map(migrations, func(item *Migration, _ int) string { return item.String() }),
+			lo.Map(dialectMigrations, func(item *Migration, _ int) string { return item.String() }))
+	}
+
+	migrator := NewMigrator(prepareMigrationDB(t), migrations)
+	applied, err := migrator.Up(ctx, 0)
+	require.Nil(t, err)
+	require.Len(t, applied, len(migrations))
+	rolledBack, err := migrator.Down(ctx, len(migrations))
+	require.Nil(t, err)
+	require.Len(t, rolledBack, len(migrations))
+	applied, err = migrator.Up(ctx, 0)
+	require.Nil(t, err)
+	require.Len(t, applied, len(migrations))
+}
+
+// the sha256 of build/ddl.sql, which created the tables at start before the migrations
+const baselineDDLChecksum = "b3a25c51c3e3a4d31e39712b56e4d87858a720fb52727b673a79690b693c8c28"
+
+func TestMigrator_UpgradeBaseline(t *testing.T) {
+	ctx := context.Background()
+	dbType := db.DBTypeSqlite
+	var bobDB *bob.DB
+	if dsn := os.Getenv(TestPostgresDSNEnv); dsn != "" {
+		dbType = db.DBTypePostgres
+		sqlDB := openPostgresTestSchema(t, dsn, fmt.Sprintf("test_baseline_%d", time.Now().UnixNano()))
+		t.Cleanup(func() {
+			_ = sqlDB.Close()
+		})
+		bobDB = &bob.DB{DB: sqlDB.DB}
+	} else {
+		bobDB = prepareMigrationDB(t)
+	}
+
+	// 000001 is the baseline ddl, so the dbs created by it at start can be upgraded
+	postgresMigrations, err := LoadBuiltinMigrations(db.DBTypePostgres)
+	require.Nil(t, err)
+	require.Equal(t, baselineDDLChecksum, postgresMigrations[0].Checksum)
+	_, err = bobDB.ExecContext(ctx, postgresMigrations[0].Up)
+	require.Nil(t, err)
+	baselineTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	_, err = bobDB.ExecContext(ctx, `insert into "user" ("id", "create_time", "update_time", "delete_time", "nickname") values ($1, $2, $2, $3, 'alice')`,
+		1, baselineTime, time.Time{})
+	require.Nil(t, err)
+	_, err = bobDB.ExecContext(ctx, `insert into "user_auth" ("id", "create_time", "update_time", "delete_time", "user_id", "auth_type", "auth_id", "last_login_time")
+values (2, $1, $1, $2, 1, 'username', 'alice', $1)`, baselineTime, time.Time{})
+	require.Nil(t, err)
+
+	migrations, err := LoadBuiltinMigrations(dbType)
+	require.Nil(t, err)
+	applied, err := NewMigrator(bobDB, migrations).Up(ctx, 0)
+	require.Nil(t, err)
+	require.Len(t, applied, len(migrations))
+
+	// the baseline rows are kept with the added columns
+	user, err := model.FindUser(ctx, bobDB, 1)
+	require.Nil(t, err)
+	require.Equal(t, "alice", user.Nickname)
+	require.Zero(t, user.TokenGeneration)
+	require.True(t, baselineTime.Equal(user.CreateTime))
+	userAuth, err := model.FindUserAuth(ctx, bobDB, 2)
+	require.Nil(t, err)
+	require.Equal(t, "alice", userAuth.AuthID)
+
+	// the widened auth_id and the unique index of the active auths
+	_, err = model.UserAuths.Insert(&model.UserAuthSetter{
+		ID:       lo.ToPtr(int64(3)),
+		AuthType: lo.ToPtr("oidc:google"),
+		AuthID:   lo.ToPtr(strings.Repeat("a", 255)),
+	}).One(ctx, bobDB)
+	require.Nil(t, err)
+	_, err = model.UserAuths.Insert(&model.UserAuthSetter{
+		ID:       lo.ToPtr(int64(4)),
+		AuthType: lo.ToPtr("username"),
+		AuthID:   lo.ToPtr("alice"),
+	}).One(ctx, bobDB)
+	require.NotNil(t, err)
+
+	// the added tables
+	count, err := model.AuditLogs.Query().Count(ctx, bobDB)
+	require.Nil(t, err)
+	require.Zero(t, count)
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, dialect := range Dialects {
+		require.Nil(t, os.Mkdir(filepath.Join(dir, string(dialect)), 0o755))
+	}
+	require.Nil(t, os.WriteFile(filepath.Join(dir, string(db.DBTypePostgres), "000001_create_a.up.sql"), []byte("-- create_a"), 0o644))
+	files, err := CreateMigration(dir, "add_a_name")
+	require.Nil(t, err)
+	require.Len(t, files, 2*len(Dialects))
+	for _, dialect := range Dialects {
+		require.Contains(t, files, filepath.Join(dir, string(dialect), "000002_add_a_name.up.sql"))
+		require.Contains(t, files, filepath.Join(dir, string(dialect), "000002_add_a_name.down.sql"))
+	}
+	_, err = CreateMigration(dir, "Bad-Name")
+	require.NotNil(t, err)
+}
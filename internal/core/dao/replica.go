@@ -0,0 +1,140 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"net/url"
+	"sync/atomic"
+
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/config"
+	"github.com/zunkk/go-sidecar/db"
+)
+
+type forcePrimaryContextKey struct{}
+
+// ForcePrimary returns a context whose reads on ReadExecutor go to the primary,
+// e.g. to read the rows just written before the replicas catch up
+func ForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcePrimaryContextKey{}, true)
+}
+
+func isPrimaryForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(forcePrimaryContextKey{}).(bool)
+	return forced
+}
+
+type replica struct {
+	// the host of the dsn, so the password is not logged
+	name string
+	db   *bob.DB
+	// executor is db with the queries instrumented
+	executor bob.Executor
+	health   dbHealth
+}
+
+func openReplicas(cfg config.DB) ([]*replica, error) {
+	if len(cfg.ReplicaDSNs) == 0 {
+		return nil, nil
+	}
+	if cfg.Type != db.DBTypePostgres {
+		return nil, errors.Errorf("read replicas are not supported by db type %s", cfg.Type)
+	}
+	replicas := make([]*replica, 0, len(cfg.ReplicaDSNs))
+	for i, dsn := range cfg.ReplicaDSNs {
+		sqlDB, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open replica %d", i+1)
+		}
+		applyPoolConfig(sqlDB, cfg)
+		replicas = append(replicas, &replica{
+			name: replicaName(i, dsn),
+			db:   &bob.DB{DB: sqlDB},
+		})
+	}
+	return replicas, nil
+}
+
+func replicaName(i int, dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return fmt.Sprintf("replica-%d", i+1)
+}
+
+// pingReplicas records the health of the replicas, an unhealthy replica is skipped until a later check succeeds
+func (c *SQLConnector) pingReplicas(ctx context.Context) {
+	for _, replica := range c.replicas {
+		// the failure has been logged
+		_ = replica.health.ping(ctx, replica.db, replica.name)
+	}
+}
+
+// readExecutor spreads the reads over the healthy replicas in turn,
+// the reads go to the primary inside a transaction, with ForcePrimary or if no replica is healthy,
+// the execs always go to the primary, they may write
+type readExecutor struct {
+	primary  contextExecutor
+	replicas []*replica
+	next     *atomic.Uint64
+}
+
+func newReadExecutor(primary contextExecutor, replicas []*replica) readExecutor {
+	return readExecutor{
+		primary:  primary,
+		replicas: replicas,
+		next:     new(atomic.Uint64),
+	}
+}
+
+func (e readExecutor) replica(ctx context.Context) *replica {
+	if len(e.replicas) == 0 || isPrimaryForced(ctx) {
+		return nil
+	}
+	if _, ok := unitOfWorkFromContext(ctx); ok {
+		return nil
+	}
+	start := e.next.Add(1)
+	for i := range uint64(len(e.replicas)) {
+		replica := e.replicas[(start+i)%uint64(len(e.replicas))]
+		if replica.health.healthy() {
+			return replica
+		}
+	}
+	return nil
+}
+
+func (e readExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	if replica := e.replica(ctx); replica != nil {
+		rows, err := replica.executor.QueryContext(ctx, query, args...)
+		if !isConnectionError(err) {
+			return rows, err
+		}
+		replica.health.record(replica.name, err)
+	}
+	return e.primary.QueryContext(ctx, query, args...)
+}
+
+func (e readExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return e.primary.ExecContext(ctx, query, args...)
+}
+
+func (e readExecutor) nextID() (int64, error) {
+	return e.primary.nextID()
+}
+
+// isConnectionError reports whether the replica can not be reached, so the read can fall back to the primary
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.As(err, &netErr)
+}
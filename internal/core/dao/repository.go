@@ -0,0 +1,112 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/dm"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+	"github.com/stephenafamo/bob/orm"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+)
+
+// the columns every table has
+const (
+	idColumn         = "id"
+	updateTimeColumn = "update_time"
+	deleteTimeColumn = "delete_time"
+	delStateColumn   = "del_state"
+	versionColumn    = "version"
+)
+
+// Repository wraps a generated table with the soft delete columns, its queries, updates and hard deletes
+// only see the active rows unless WithDeleted is used, so a forgotten del_state filter can not leak deleted rows
+type Repository[T any, Tslice ~[]T, Tset orm.Setter[T, *dialect.InsertQuery, *dialect.UpdateQuery]] struct {
+	table       *psql.Table[T, Tslice, Tset]
+	withDeleted bool
+}
+
+func NewRepository[T any, Tslice ~[]T, Tset orm.Setter[T, *dialect.InsertQuery, *dialect.UpdateQuery]](table *psql.Table[T, Tslice, Tset]) *Repository[T, Tslice, Tset] {
+	return &Repository[T, Tslice, Tset]{
+		table: table,
+	}
+}
+
+// WithDeleted returns a repository seeing the rows of all del states
+func (r *Repository[T, Tslice, Tset]) WithDeleted() *Repository[T, Tslice, Tset] {
+	return &Repository[T, Tslice, Tset]{
+		table:       r.table,
+		withDeleted: true,
+	}
+}
+
+func (r *Repository[T, Tslice, Tset]) Query(queryMods ...bob.Mod[*dialect.SelectQuery]) *psql.ViewQuery[T, Tslice] {
+	if !r.withDeleted {
+		queryMods = append(queryMods, sm.Where(r.column(delStateColumn).EQ(psql.Arg(entity.DelStateActive))))
+	}
+	return r.table.Query(queryMods...)
+}
+
+// FindByID returns an error wrapping sql.ErrNoRows if the row does not exist
+func (r *Repository[T, Tslice, Tset]) FindByID(ctx context.Context, exec bob.Executor, id int64) (T, error) {
+	return r.Query(sm.Where(r.column(idColumn).EQ(psql.Arg(id)))).One(ctx, exec)
+}
+
+func (r *Repository[T, Tslice, Tset]) Update(queryMods ...bob.Mod[*dialect.UpdateQuery]) *orm.Query[*dialect.UpdateQuery, T, Tslice] {
+	if !r.withDeleted {
+		queryMods = append(queryMods, um.Where(r.column(delStateColumn).EQ(psql.Arg(entity.DelStateActive))))
+	}
+	return r.table.Update(queryMods...)
+}
+
+// SoftDelete marks the matched active rows deleted at deleteTime,
+// rows deleted together share the delete time so they can be restored together
+func (r *Repository[T, Tslice, Tset]) SoftDelete(ctx context.Context, exec bob.Executor, deleteTime time.Time, queryMods ...bob.Mod[*dialect.UpdateQuery]) (int64, error) {
+	queryMods = append(queryMods,
+		um.SetCol(updateTimeColumn).ToArg(deleteTime.UTC()),
+		um.SetCol(deleteTimeColumn).ToArg(deleteTime.UTC()),
+		um.SetCol(delStateColumn).ToArg(entity.DelStateDeleted),
+		um.Where(r.column(delStateColumn).EQ(psql.Arg(entity.DelStateActive))),
+	)
+	deleted, err := r.table.Update(queryMods...).Exec(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to soft delete %s", r.table.Alias())
+	}
+	return deleted, nil
+}
+
+// Restore makes the matched deleted rows active again, erased rows stay erased
+func (r *Repository[T, Tslice, Tset]) Restore(ctx context.Context, exec bob.Executor, queryMods ...bob.Mod[*dialect.UpdateQuery]) (int64, error) {
+	queryMods = append(queryMods,
+		um.SetCol(deleteTimeColumn).ToArg(time.Time{}),
+		um.SetCol(delStateColumn).ToArg(entity.DelStateActive),
+		um.Where(r.column(delStateColumn).EQ(psql.Arg(entity.DelStateDeleted))),
+	)
+	restored, err := r.table.Update(queryMods...).Exec(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to restore %s", r.table.Alias())
+	}
+	return restored, nil
+}
+
+// HardDelete physically deletes the matched rows
+func (r *Repository[T, Tslice, Tset]) HardDelete(ctx context.Context, exec bob.Executor, queryMods ...bob.Mod[*dialect.DeleteQuery]) (int64, error) {
+	if !r.withDeleted {
+		queryMods = append(queryMods, dm.Where(r.column(delStateColumn).EQ(psql.Arg(entity.DelStateActive))))
+	}
+	deleted, err := r.table.Delete(queryMods...).Exec(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete %s", r.table.Alias())
+	}
+	return deleted, nil
+}
+
+func (r *Repository[T, Tslice, Tset]) column(name string) psql.Expression {
+	return psql.Quote(r.table.Alias(), name)
+}
@@ -0,0 +1,67 @@
+package dao
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+)
+
+func TestRepository(t *testing.T) {
+	sidecar := base.NewMockCustomSidecar(t)
+	sqlConnector := NewMockSQLConnector(t, sidecar)
+	ctx := sidecar.BackgroundContext().Ctx
+	for _, id := range []int64{1, 2, 3} {
+		_, err := model.Users.Insert(&model.UserSetter{
+			ID: lo.ToPtr(id),
+		}).Exec(ctx, sqlConnector.DB)
+		require.Nil(t, err)
+	}
+
+	users := NewRepository(model.Users)
+	deleted, err := users.SoftDelete(ctx, sqlConnector.DB, time.Now(), model.UpdateWhere.Users.ID.In(1, 2))
+	require.Nil(t, err)
+	require.Equal(t, int64(2), deleted)
+	// deleted rows are not deleted again
+	deleted, err = users.SoftDelete(ctx, sqlConnector.DB, time.Now(), model.UpdateWhere.Users.ID.EQ(1))
+	require.Nil(t, err)
+	require.Zero(t, deleted)
+
+	_, err = users.FindByID(ctx, sqlConnector.DB, 1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	user, err := users.WithDeleted().FindByID(ctx, sqlConnector.DB, 1)
+	require.Nil(t, err)
+	require.Equal(t, entity.DelStateDeleted, user.DelState)
+	require.Equal(t, int64(1), user.Version)
+	count, err := users.Query().Count(ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), count)
+	count, err = users.WithDeleted().Query().Count(ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), count)
+	updated, err := users.Update(model.UserSetter{Nickname: lo.ToPtr("x")}.UpdateMod()).Exec(ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), updated)
+
+	restored, err := users.Restore(ctx, sqlConnector.DB, model.UpdateWhere.Users.ID.In(1, 3))
+	require.Nil(t, err)
+	require.Equal(t, int64(1), restored)
+	user, err = users.FindByID(ctx, sqlConnector.DB, 1)
+	require.Nil(t, err)
+	require.True(t, user.DeleteTime.IsZero())
+	require.Equal(t, int64(2), user.Version)
+
+	// the hard delete skips the deleted rows unless WithDeleted is used
+	deleted, err = users.HardDelete(ctx, sqlConnector.DB, model.DeleteWhere.Users.ID.In(1, 2))
+	require.Nil(t, err)
+	require.Equal(t, int64(1), deleted)
+	deleted, err = users.WithDeleted().HardDelete(ctx, sqlConnector.DB, model.DeleteWhere.Users.ID.In(1, 2))
+	require.Nil(t, err)
+	require.Equal(t, int64(1), deleted)
+}
@@ -0,0 +1,237 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// DBAction runs with the context carrying the transaction, so the services called with it join the transaction
+type DBAction func(ctx context.Context, dbTX bob.Transaction) error
+
+type txContextKey struct{}
+
+// unitOfWork is the outermost transaction carried by the context,
+// like sql.Tx it must not be used by multiple goroutines at the same time
+type unitOfWork struct {
+	tx          bob.Transaction
+	savepoints  int
+	afterCommit []func(ctx context.Context) error
+}
+
+func unitOfWorkFromContext(ctx context.Context) (*unitOfWork, bool) {
+	uow, ok := ctx.Value(txContextKey{}).(*unitOfWork)
+	return uow, ok
+}
+
+// savepoint is the transaction of a nested SubmitDBChangesByTransaction, its rollback only discards the changes made after it
+type savepoint struct {
+	bob.Transaction
+	name string
+}
+
+func (s savepoint) Commit(ctx context.Context) error {
+	_, err := s.ExecContext(ctx, "release savepoint "+s.name)
+	return err
+}
+
+func (s savepoint) Rollback(ctx context.Context) error {
+	_, err := s.ExecContext(ctx, "rollback to savepoint "+s.name)
+	return err
+}
+
+func (s savepoint) nextID() (int64, error) {
+	return nextID(s.Transaction)
+}
+
+type txBeginner interface {
+	bob.Executor
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (bob.Transaction, error)
+}
+
+type contextExecutor struct {
+	db bob.Executor
+}
+
+func (e contextExecutor) executor(ctx context.Context) bob.Executor {
+	if uow, ok := unitOfWorkFromContext(ctx); ok {
+		return uow.tx
+	}
+	return e.db
+}
+
+func (e contextExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	return e.executor(ctx).QueryContext(ctx, query, args...)
+}
+
+func (e contextExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return e.executor(ctx).ExecContext(ctx, query, args...)
+}
+
+func (e contextExecutor) nextID() (int64, error) {
+	return nextID(e.db)
+}
+
+// AfterCommit registers fn to run after the outermost transaction of the context commits,
+// fn is dropped if the transaction or the savepoint it is registered in rolls back.
+// fn runs at once if the context carries no transaction
+func AfterCommit(ctx context.Context, fn func(ctx context.Context) error) error {
+	uow, ok := unitOfWorkFromContext(ctx)
+	if !ok {
+		return fn(ctx)
+	}
+	uow.afterCommit = append(uow.afterCommit, fn)
+	return nil
+}
+
+// TxOptions are the options of the outermost transaction, a nested call joining the transaction ignores them
+type TxOptions struct {
+	// Isolation is the isolation level of the transaction, the driver default if zero
+	Isolation sql.IsolationLevel
+	// MaxAttempts overrides db.tx_max_attempts if positive, 1 disables the retry
+	MaxAttempts int
+}
+
+// SubmitDBChangesByTransaction runs the actions in a new transaction,
+// or in a savepoint of the transaction carried by the context
+func (c *SQLConnector) SubmitDBChangesByTransaction(ctx context.Context, dbActions ...DBAction) error {
+	return c.SubmitDBChangesByTransactionWithOptions(ctx, TxOptions{}, dbActions...)
+}
+
+// SubmitDBChangesByTransactionWithOptions is SubmitDBChangesByTransaction with the options,
+// the whole actions run again on the retryable errors (see IsRetryableError), so they must not leave side effects outside the db,
+// use AfterCommit for those
+func (c *SQLConnector) SubmitDBChangesByTransactionWithOptions(ctx context.Context, opts TxOptions, dbActions ...DBAction) error {
+	if _, ok := unitOfWorkFromContext(ctx); ok {
+		// the outermost transaction owns the isolation level and the retry
+		return submitDBChangesByTransaction(ctx, c.db, nil, dbActions...)
+	}
+
+	cfg := c.sidecar.Repo.Cfg.DB
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = max(cfg.TxMaxAttempts, 1)
+	}
+	for attempt := 1; ; attempt++ {
+		err := submitDBChangesByTransaction(ctx, c.db, &sql.TxOptions{Isolation: opts.Isolation}, dbActions...)
+		if err == nil || attempt >= maxAttempts || !IsRetryableError(err) {
+			return err
+		}
+		delay := txRetryDelay(attempt, cfg.TxRetryBaseDelay.ToDuration(), cfg.TxRetryMaxDelay.ToDuration())
+		log.Warn("Retry db transaction", "attempt", attempt, "delay", delay, "err", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// IsRetryableError reports whether the transaction failed by the concurrent transactions and may succeed if it runs again:
+// serialization failures (SQLSTATE 40001) and deadlocks (SQLSTATE 40P01) of postgres, busy or locked sqlite db
+func IsRetryableError(err error) bool {
+	var sqlStateErr interface{ SQLState() string }
+	if errors.As(err, &sqlStateErr) {
+		switch sqlStateErr.SQLState() {
+		case "40001", "40P01":
+			return true
+		}
+		return false
+	}
+	msg := err.Error()
+	for _, sqliteMsg := range []string{"database is locked", "database table is locked", "SQLITE_BUSY", "SQLITE_LOCKED"} {
+		if strings.Contains(msg, sqliteMsg) {
+			return true
+		}
+	}
+	return false
+}
+
+// txRetryDelay returns a random delay between the half and the whole of baseDelay doubled on every attempt, limited by maxDelay
+func txRetryDelay(attempt int, baseDelay time.Duration, maxDelay time.Duration) time.Duration {
+	if baseDelay <= 0 {
+		return 0
+	}
+	delay := baseDelay << min(attempt-1, 30)
+	if delay <= 0 {
+		// overflowed
+		delay = math.MaxInt64
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func submitDBChangesByTransaction(ctx context.Context, db txBeginner, txOptions *sql.TxOptions, dbActions ...DBAction) error {
+	if uow, ok := unitOfWorkFromContext(ctx); ok {
+		return submitDBChangesBySavepoint(ctx, uow, dbActions...)
+	}
+
+	dbTX, err := db.BeginTx(ctx, txOptions)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin db transaction")
+	}
+	// The rollback will be ignored if the tx has been committed later in the function.
+	defer func() {
+		if err != nil {
+			if rollbackErr := dbTX.Rollback(ctx); rollbackErr != nil {
+				log.Warn("Failed to rollback", "err", rollbackErr)
+			}
+		}
+	}()
+	uow := &unitOfWork{tx: dbTX}
+	txCtx := context.WithValue(ctx, txContextKey{}, uow)
+	for _, dbAction := range dbActions {
+		if err = dbAction(txCtx, dbTX); err != nil {
+			return err
+		}
+	}
+	if err = dbTX.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to commit db transaction")
+	}
+
+	// the changes have been committed, so the failed callbacks are only logged
+	for _, fn := range uow.afterCommit {
+		if callbackErr := fn(ctx); callbackErr != nil {
+			log.Warn("Failed to run after commit callback", "err", callbackErr)
+		}
+	}
+	return nil
+}
+
+func submitDBChangesBySavepoint(ctx context.Context, uow *unitOfWork, dbActions ...DBAction) error {
+	uow.savepoints++
+	dbTX := savepoint{Transaction: uow.tx, name: fmt.Sprintf("sp_%d", uow.savepoints)}
+	if _, err := uow.tx.ExecContext(ctx, "savepoint "+dbTX.name); err != nil {
+		return errors.Wrap(err, "failed to create savepoint")
+	}
+	afterCommitCount := len(uow.afterCommit)
+
+	var err error
+	defer func() {
+		if err != nil {
+			if rollbackErr := dbTX.Rollback(ctx); rollbackErr != nil {
+				log.Warn("Failed to rollback to savepoint", "savepoint", dbTX.name, "err", rollbackErr)
+			}
+			uow.afterCommit = uow.afterCommit[:afterCommitCount]
+		}
+	}()
+	for _, dbAction := range dbActions {
+		if err = dbAction(ctx, dbTX); err != nil {
+			return err
+		}
+	}
+	if err = dbTX.Commit(ctx); err != nil {
+		return errors.Wrap(err, "failed to release savepoint")
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+)
+
+func TestSubmitDBChangesByTransaction(t *testing.T) {
+	sidecar := base.NewMockCustomSidecar(t)
+	sqlConnector := NewMockSQLConnector(t, sidecar)
+	ctx := sidecar.BackgroundContext().Ctx
+
+	insertUser := func(id int64) DBAction {
+		return func(ctx context.Context, dbTX bob.Transaction) error {
+			// the executor joins the transaction of the context
+			_, err := model.Users.Insert(&model.UserSetter{ID: lo.ToPtr(id)}).Exec(ctx, sqlConnector.Executor)
+			return err
+		}
+	}
+	userExists := func(id int64) bool {
+		exists, err := model.UserExists(ctx, sqlConnector.DB, id)
+		require.Nil(t, err)
+		return exists
+	}
+	errFailed := errors.New("failed")
+
+	var committed []string
+	err := sqlConnector.SubmitDBChangesByTransaction(ctx, insertUser(1), func(ctx context.Context, dbTX bob.Transaction) error {
+		require.Nil(t, AfterCommit(ctx, func(ctx context.Context) error {
+			committed = append(committed, "outer")
+			return nil
+		}))
+		// the failed nested call only rolls back its own changes and callbacks
+		err := sqlConnector.SubmitDBChangesByTransaction(ctx, insertUser(2), func(ctx context.Context, dbTX bob.Transaction) error {
+			require.Nil(t, AfterCommit(ctx, func(ctx context.Context) error {
+				committed = append(committed, "failed")
+				return nil
+			}))
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+		require.Nil(t, sqlConnector.SubmitDBChangesByTransaction(ctx, insertUser(3), func(ctx context.Context, dbTX bob.Transaction) error {
+			return AfterCommit(ctx, func(ctx context.Context) error {
+				committed = append(committed, "nested")
+				return nil
+			})
+		}))
+		require.Empty(t, committed)
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"outer", "nested"}, committed)
+	require.True(t, userExists(1))
+	require.False(t, userExists(2))
+	require.True(t, userExists(3))
+
+	// the failed outer call rolls back the committed nested calls
+	committed = nil
+	err = sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		require.Nil(t, sqlConnector.SubmitDBChangesByTransaction(ctx, insertUser(4), func(ctx context.Context, dbTX bob.Transaction) error {
+			return AfterCommit(ctx, func(ctx context.Context) error {
+				committed = append(committed, "nested")
+				return nil
+			})
+		}))
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+	require.Empty(t, committed)
+	require.False(t, userExists(4))
+
+	// the callback runs at once without a transaction
+	require.Nil(t, AfterCommit(ctx, func(ctx context.Context) error {
+		committed = append(committed, "direct")
+		return nil
+	}))
+	require.Equal(t, []string{"direct"}, committed)
+}
+
+func TestSubmitDBChangesByTransactionWithOptions(t *testing.T) {
+	sidecar := base.NewMockCustomSidecar(t)
+	sidecar.Repo.Cfg.DB.TxRetryBaseDelay = 0
+	sqlConnector := NewMockSQLConnector(t, sidecar)
+	ctx := sidecar.BackgroundContext().Ctx
+
+	// a retryable error reruns the whole actions with a new transaction
+	attempts := 0
+	var committed []int
+	err := sqlConnector.SubmitDBChangesByTransactionWithOptions(ctx, TxOptions{Isolation: sql.LevelSerializable}, func(ctx context.Context, dbTX bob.Transaction) error {
+		attempts++
+		if _, err := model.Users.Insert(&model.UserSetter{ID: lo.ToPtr(int64(1))}).Exec(ctx, dbTX); err != nil {
+			return err
+		}
+		attempt := attempts
+		if err := AfterCommit(ctx, func(ctx context.Context) error {
+			committed = append(committed, attempt)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return errors.Wrap(&pq.Error{Code: "40001"}, "failed to update")
+		}
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, []int{3}, committed)
+
+	attempts = 0
+	err = sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		attempts++
+		return errors.New("database is locked")
+	})
+	require.NotNil(t, err)
+	require.Equal(t, sidecar.Repo.Cfg.DB.TxMaxAttempts, attempts)
+
+	attempts = 0
+	err = sqlConnector.SubmitDBChangesByTransactionWithOptions(ctx, TxOptions{MaxAttempts: 1}, func(ctx context.Context, dbTX bob.Transaction) error {
+		attempts++
+		return &pq.Error{Code: "40P01"}
+	})
+	require.NotNil(t, err)
+	require.Equal(t, 1, attempts)
+
+	// only the outermost transaction retries
+	attempts = 0
+	err = sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		return sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+			attempts++
+			return &pq.Error{Code: "40001"}
+		})
+	})
+	require.NotNil(t, err)
+	require.Equal(t, sidecar.Repo.Cfg.DB.TxMaxAttempts, attempts)
+}
+
+func TestIsRetryableError(t *testing.T) {
+	require.True(t, IsRetryableError(&pq.Error{Code: "40001"}))
+	require.True(t, IsRetryableError(errors.Wrap(&pq.Error{Code: "40P01"}, "failed to commit db transaction")))
+	require.False(t, IsRetryableError(&pq.Error{Code: "23505"}))
+	require.True(t, IsRetryableError(errors.New("database is locked (5) (SQLITE_BUSY)")))
+	require.True(t, IsRetryableError(errors.New("database table is locked")))
+	require.False(t, IsRetryableError(errors.New("no such table: user")))
+}
+
+func TestTxRetryDelay(t *testing.T) {
+	for attempt := 1; attempt <= 40; attempt++ {
+		delay := txRetryDelay(attempt, 10*time.Millisecond, time.Second)
+		expected := min(10*time.Millisecond<<min(attempt-1, 30), time.Second)
+		require.GreaterOrEqual(t, delay, expected/2)
+		require.LessOrEqual(t, delay, expected)
+	}
+	require.Zero(t, txRetryDelay(1, 0, 0))
+}
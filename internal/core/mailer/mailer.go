@@ -0,0 +1,214 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-project-startup/internal/pkg/config"
+	"github.com/zunkk/go-sidecar/frame"
+	glog "github.com/zunkk/go-sidecar/log"
+)
+
+var log = glog.WithModule("mailer")
+
+func init() {
+	frame.RegisterComponents(New)
+}
+
+const (
+	TypeSMTP   = "smtp"
+	TypeFile   = "file"
+	TypeMemory = "memory"
+
+	// FileDirName is the directory under the repo path where the file mailer writes mails
+	FileDirName = "mails"
+
+	// smtpTimeout bounds a whole smtp session when the ctx has no earlier deadline
+	smtpTimeout = 30 * time.Second
+)
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, mail Mail) error
+}
+
+func New(sidecar *base.CustomSidecar) (Mailer, error) {
+	cfg := sidecar.Repo.Cfg.Mail
+	switch cfg.Type {
+	case TypeSMTP:
+		if cfg.SMTPHost == "" || cfg.From == "" {
+			return nil, errors.New("mail.smtp_host and mail.from are required by smtp mailer")
+		}
+		return NewSMTPMailer(cfg), nil
+	case TypeFile:
+		return NewFileMailer(cfg, filepath.Join(sidecar.Repo.RepoPath, FileDirName)), nil
+	case TypeMemory:
+		return NewMemoryMailer(), nil
+	default:
+		return nil, errors.Errorf("unsupported mail.type: %s", cfg.Type)
+	}
+}
+
+type SMTPMailer struct {
+	cfg config.Mail
+}
+
+func NewSMTPMailer(cfg config.Mail) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send delivers the mail by the smtp server, STARTTLS is used when the server supports it.
+// The session is aborted when the ctx is done or smtpTimeout passes
+func (m *SMTPMailer) Send(ctx context.Context, mail Mail) error {
+	if err := m.send(ctx, mail); err != nil {
+		return errors.Wrapf(err, "failed to send mail to %s", mail.To)
+	}
+	return nil
+}
+
+func (m *SMTPMailer) send(ctx context.Context, mail Mail) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(m.cfg.SMTPHost, strconv.Itoa(m.cfg.SMTPPort))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	// unblocks the session if the ctx is canceled before the deadline
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	client, err := smtp.NewClient(conn, m.cfg.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.cfg.SMTPHost}); err != nil {
+			return err
+		}
+	}
+	if m.cfg.SMTPUsername != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support AUTH")
+		}
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.SMTPUsername, m.cfg.SMTPPassword, m.cfg.SMTPHost)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(m.cfg.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(mail.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(encode(m.cfg.From, mail)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// FileMailer writes every mail as an eml file, it stands in for the smtp server in local development
+type FileMailer struct {
+	cfg config.Mail
+	dir string
+}
+
+func NewFileMailer(cfg config.Mail, dir string) *FileMailer {
+	return &FileMailer{cfg: cfg, dir: dir}
+}
+
+func (m *FileMailer) Send(ctx context.Context, mail Mail) error {
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return errors.Wrapf(err, "failed to create mail dir %s", m.dir)
+	}
+	path := filepath.Join(m.dir, fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), mail.To))
+	if err := os.WriteFile(path, encode(m.cfg.From, mail), 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write mail file %s", path)
+	}
+	log.Info("Mail written to file", "to", mail.To, "path", path)
+	return nil
+}
+
+// MemoryMailer keeps sent mails in memory, used by tests
+type MemoryMailer struct {
+	lock  sync.Mutex
+	mails []Mail
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, mail Mail) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.mails = append(m.mails, mail)
+	return nil
+}
+
+func (m *MemoryMailer) Mails() []Mail {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]Mail(nil), m.mails...)
+}
+
+// Last returns the latest mail sent to the address
+func (m *MemoryMailer) Last(to string) (Mail, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i := len(m.mails) - 1; i >= 0; i-- {
+		if m.mails[i].To == to {
+			return m.mails[i], true
+		}
+	}
+	return Mail{}, false
+}
+
+func encode(from string, mail Mail) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + mail.To + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", mail.Subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(mail.Body)
+	return buf.Bytes()
+}
@@ -0,0 +1,114 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/config"
+)
+
+// fakeSMTPServer speaks the plain smtp commands used by SMTPMailer and passes every received message to the channel
+func fakeSMTPServer(t *testing.T, messages chan<- string) config.Mail {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		listener.Close()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, messages)
+		}
+	}()
+	return smtpConfig(listener.Addr())
+}
+
+func serveSMTP(conn net.Conn, messages chan<- string) {
+	defer conn.Close()
+	tc := textproto.NewConn(conn)
+	_ = tc.PrintfLine("220 fake smtp")
+	for {
+		line, err := tc.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO", "MAIL", "RCPT":
+			_ = tc.PrintfLine("250 ok")
+		case "DATA":
+			_ = tc.PrintfLine("354 go ahead")
+			data, err := tc.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			messages <- string(data)
+			_ = tc.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tc.PrintfLine("221 bye")
+			return
+		default:
+			_ = tc.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func smtpConfig(addr net.Addr) config.Mail {
+	tcpAddr := addr.(*net.TCPAddr)
+	return config.Mail{
+		Type:     TypeSMTP,
+		From:     "noreply@example.com",
+		SMTPHost: tcpAddr.IP.String(),
+		SMTPPort: tcpAddr.Port,
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	messages := make(chan string, 1)
+	smtpMailer := NewSMTPMailer(fakeSMTPServer(t, messages))
+
+	err := smtpMailer.Send(context.Background(), Mail{
+		To:      "alice@example.com",
+		Subject: "Verification code",
+		Body:    "Your verification code is 123456",
+	})
+	require.Nil(t, err)
+
+	message := <-messages
+	require.Contains(t, message, "From: noreply@example.com")
+	require.Contains(t, message, "To: alice@example.com")
+	require.Contains(t, message, "Your verification code is 123456")
+}
+
+func TestSMTPMailer_SendCanceled(t *testing.T) {
+	// the server accepts the connection but never greets
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = bufio.NewReader(conn).ReadString('\n')
+	}()
+	smtpMailer := NewSMTPMailer(smtpConfig(listener.Addr()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = smtpMailer.Send(ctx, Mail{To: "alice@example.com", Subject: "hello", Body: "hello"})
+	require.NotNil(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
@@ -19,27 +19,156 @@ import (
 )
 
 var TableNames = struct {
-	Users     string
-	UserAuths string
+	APIKeys           string
+	AuditLogs         string
+	LoginAttempts     string
+	OauthStates       string
+	Permissions       string
+	RecoveryCodes     string
+	RefreshTokens     string
+	RolePermissions   string
+	Users             string
+	UserAuths         string
+	VerificationCodes string
 }{
-	Users:     "user",
-	UserAuths: "user_auth",
+	APIKeys:           "api_key",
+	AuditLogs:         "audit_log",
+	LoginAttempts:     "login_attempt",
+	OauthStates:       "oauth_state",
+	Permissions:       "permission",
+	RecoveryCodes:     "recovery_code",
+	RefreshTokens:     "refresh_token",
+	RolePermissions:   "role_permission",
+	Users:             "user",
+	UserAuths:         "user_auth",
+	VerificationCodes: "verification_code",
 }
 
 var ColumnNames = struct {
-	Users     userColumnNames
-	UserAuths userAuthColumnNames
+	APIKeys           apiKeyColumnNames
+	AuditLogs         auditLogColumnNames
+	LoginAttempts     loginAttemptColumnNames
+	OauthStates       oauthStateColumnNames
+	Permissions       permissionColumnNames
+	RecoveryCodes     recoveryCodeColumnNames
+	RefreshTokens     refreshTokenColumnNames
+	RolePermissions   rolePermissionColumnNames
+	Users             userColumnNames
+	UserAuths         userAuthColumnNames
+	VerificationCodes verificationCodeColumnNames
 }{
-	Users: userColumnNames{
+	APIKeys: apiKeyColumnNames{
+		ID:           "id",
+		CreateTime:   "create_time",
+		UpdateTime:   "update_time",
+		DeleteTime:   "delete_time",
+		DelState:     "del_state",
+		Version:      "version",
+		Name:         "name",
+		Prefix:       "prefix",
+		KeyHash:      "key_hash",
+		Scopes:       "scopes",
+		CreatorID:    "creator_id",
+		ExpireTime:   "expire_time",
+		LastUsedTime: "last_used_time",
+	},
+	AuditLogs: auditLogColumnNames{
+		ID:         "id",
+		CreateTime: "create_time",
+		UpdateTime: "update_time",
+		DeleteTime: "delete_time",
+		DelState:   "del_state",
+		Version:    "version",
+		Actor:      "actor",
+		Action:     "action",
+		Target:     "target",
+		Diff:       "diff",
+		ClientIP:   "client_ip",
+		RequestID:  "request_id",
+	},
+	LoginAttempts: loginAttemptColumnNames{
+		ID:           "id",
+		CreateTime:   "create_time",
+		UpdateTime:   "update_time",
+		DeleteTime:   "delete_time",
+		DelState:     "del_state",
+		Version:      "version",
+		Scope:        "scope",
+		Subject:      "subject",
+		FailCount:    "fail_count",
+		LastFailTime: "last_fail_time",
+		LockUntil:    "lock_until",
+	},
+	OauthStates: oauthStateColumnNames{
+		ID:           "id",
+		CreateTime:   "create_time",
+		UpdateTime:   "update_time",
+		DeleteTime:   "delete_time",
+		DelState:     "del_state",
+		Version:      "version",
+		Provider:     "provider",
+		StateHash:    "state_hash",
+		Nonce:        "nonce",
+		CodeVerifier: "code_verifier",
+		UserID:       "user_id",
+		ExpireTime:   "expire_time",
+		UseTime:      "use_time",
+	},
+	Permissions: permissionColumnNames{
+		ID:          "id",
+		CreateTime:  "create_time",
+		UpdateTime:  "update_time",
+		DeleteTime:  "delete_time",
+		DelState:    "del_state",
+		Version:     "version",
+		Name:        "name",
+		Description: "description",
+	},
+	RecoveryCodes: recoveryCodeColumnNames{
+		ID:         "id",
+		CreateTime: "create_time",
+		UpdateTime: "update_time",
+		DeleteTime: "delete_time",
+		DelState:   "del_state",
+		Version:    "version",
+		UserID:     "user_id",
+		CodeHash:   "code_hash",
+		UseTime:    "use_time",
+	},
+	RefreshTokens: refreshTokenColumnNames{
+		ID:         "id",
+		CreateTime: "create_time",
+		UpdateTime: "update_time",
+		DeleteTime: "delete_time",
+		DelState:   "del_state",
+		Version:    "version",
+		UserID:     "user_id",
+		FamilyID:   "family_id",
+		TokenHash:  "token_hash",
+		ExpireTime: "expire_time",
+		RotateTime: "rotate_time",
+	},
+	RolePermissions: rolePermissionColumnNames{
 		ID:         "id",
 		CreateTime: "create_time",
 		UpdateTime: "update_time",
 		DeleteTime: "delete_time",
 		DelState:   "del_state",
 		Version:    "version",
-		Nickname:   "nickname",
-		Info:       "info",
 		Role:       "role",
+		Permission: "permission",
+	},
+	Users: userColumnNames{
+		ID:              "id",
+		CreateTime:      "create_time",
+		UpdateTime:      "update_time",
+		DeleteTime:      "delete_time",
+		DelState:        "del_state",
+		Version:         "version",
+		Nickname:        "nickname",
+		Info:            "info",
+		Role:            "role",
+		TokenGeneration: "token_generation",
 	},
 	UserAuths: userAuthColumnNames{
 		ID:            "id",
@@ -54,6 +183,20 @@ var ColumnNames = struct {
 		AuthToken:     "auth_token",
 		LastLoginTime: "last_login_time",
 	},
+	VerificationCodes: verificationCodeColumnNames{
+		ID:         "id",
+		CreateTime: "create_time",
+		UpdateTime: "update_time",
+		DeleteTime: "delete_time",
+		DelState:   "del_state",
+		Version:    "version",
+		Purpose:    "purpose",
+		Target:     "target",
+		CodeHash:   "code_hash",
+		ExpireTime: "expire_time",
+		Attempts:   "attempts",
+		UseTime:    "use_time",
+	},
 }
 
 var (
@@ -64,15 +207,42 @@ var (
 )
 
 func Where[Q psql.Filterable]() struct {
-	Users     userWhere[Q]
-	UserAuths userAuthWhere[Q]
+	APIKeys           apiKeyWhere[Q]
+	AuditLogs         auditLogWhere[Q]
+	LoginAttempts     loginAttemptWhere[Q]
+	OauthStates       oauthStateWhere[Q]
+	Permissions       permissionWhere[Q]
+	RecoveryCodes     recoveryCodeWhere[Q]
+	RefreshTokens     refreshTokenWhere[Q]
+	RolePermissions   rolePermissionWhere[Q]
+	Users             userWhere[Q]
+	UserAuths         userAuthWhere[Q]
+	VerificationCodes verificationCodeWhere[Q]
 } {
 	return struct {
-		Users     userWhere[Q]
-		UserAuths userAuthWhere[Q]
+		APIKeys           apiKeyWhere[Q]
+		AuditLogs         auditLogWhere[Q]
+		LoginAttempts     loginAttemptWhere[Q]
+		OauthStates       oauthStateWhere[Q]
+		Permissions       permissionWhere[Q]
+		RecoveryCodes     recoveryCodeWhere[Q]
+		RefreshTokens     refreshTokenWhere[Q]
+		RolePermissions   rolePermissionWhere[Q]
+		Users             userWhere[Q]
+		UserAuths         userAuthWhere[Q]
+		VerificationCodes verificationCodeWhere[Q]
 	}{
-		Users:     buildUserWhere[Q](UserColumns),
-		UserAuths: buildUserAuthWhere[Q](UserAuthColumns),
+		APIKeys:           buildAPIKeyWhere[Q](APIKeyColumns),
+		AuditLogs:         buildAuditLogWhere[Q](AuditLogColumns),
+		LoginAttempts:     buildLoginAttemptWhere[Q](LoginAttemptColumns),
+		OauthStates:       buildOauthStateWhere[Q](OauthStateColumns),
+		Permissions:       buildPermissionWhere[Q](PermissionColumns),
+		RecoveryCodes:     buildRecoveryCodeWhere[Q](RecoveryCodeColumns),
+		RefreshTokens:     buildRefreshTokenWhere[Q](RefreshTokenColumns),
+		RolePermissions:   buildRolePermissionWhere[Q](RolePermissionColumns),
+		Users:             buildUserWhere[Q](UserColumns),
+		UserAuths:         buildUserAuthWhere[Q](UserAuthColumns),
+		VerificationCodes: buildVerificationCodeWhere[Q](VerificationCodeColumns),
 	}
 }
 
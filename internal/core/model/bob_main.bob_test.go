@@ -12,8 +12,35 @@ import (
 // Set the testDB to enable tests that use the database
 var testDB bob.Transactor
 
+// Make sure the type APIKey runs hooks after queries
+var _ bob.HookableType = &models.APIKey{}
+
+// Make sure the type AuditLog runs hooks after queries
+var _ bob.HookableType = &models.AuditLog{}
+
+// Make sure the type LoginAttempt runs hooks after queries
+var _ bob.HookableType = &models.LoginAttempt{}
+
+// Make sure the type OauthState runs hooks after queries
+var _ bob.HookableType = &models.OauthState{}
+
+// Make sure the type Permission runs hooks after queries
+var _ bob.HookableType = &models.Permission{}
+
+// Make sure the type RecoveryCode runs hooks after queries
+var _ bob.HookableType = &models.RecoveryCode{}
+
+// Make sure the type RefreshToken runs hooks after queries
+var _ bob.HookableType = &models.RefreshToken{}
+
+// Make sure the type RolePermission runs hooks after queries
+var _ bob.HookableType = &models.RolePermission{}
+
 // Make sure the type User runs hooks after queries
 var _ bob.HookableType = &models.User{}
 
 // Make sure the type UserAuth runs hooks after queries
 var _ bob.HookableType = &models.UserAuth{}
+
+// Make sure the type VerificationCode runs hooks after queries
+var _ bob.HookableType = &models.VerificationCode{}
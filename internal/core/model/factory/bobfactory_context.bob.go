@@ -14,14 +14,50 @@ type contextKey string
 var (
 	// Table context
 
-	userCtx     = newContextual[*models.User]("user")
-	userAuthCtx = newContextual[*models.UserAuth]("userAuth")
+	apiKeyCtx           = newContextual[*models.APIKey]("apiKey")
+	auditLogCtx         = newContextual[*models.AuditLog]("auditLog")
+	loginAttemptCtx     = newContextual[*models.LoginAttempt]("loginAttempt")
+	oauthStateCtx       = newContextual[*models.OauthState]("oauthState")
+	permissionCtx       = newContextual[*models.Permission]("permission")
+	recoveryCodeCtx     = newContextual[*models.RecoveryCode]("recoveryCode")
+	refreshTokenCtx     = newContextual[*models.RefreshToken]("refreshToken")
+	rolePermissionCtx   = newContextual[*models.RolePermission]("rolePermission")
+	userCtx             = newContextual[*models.User]("user")
+	userAuthCtx         = newContextual[*models.UserAuth]("userAuth")
+	verificationCodeCtx = newContextual[*models.VerificationCode]("verificationCode")
+
+	// Relationship Contexts for api_key
+	apiKeyWithParentsCascadingCtx = newContextual[bool]("apiKeyWithParentsCascading")
+
+	// Relationship Contexts for audit_log
+	auditLogWithParentsCascadingCtx = newContextual[bool]("auditLogWithParentsCascading")
+
+	// Relationship Contexts for login_attempt
+	loginAttemptWithParentsCascadingCtx = newContextual[bool]("loginAttemptWithParentsCascading")
+
+	// Relationship Contexts for oauth_state
+	oauthStateWithParentsCascadingCtx = newContextual[bool]("oauthStateWithParentsCascading")
+
+	// Relationship Contexts for permission
+	permissionWithParentsCascadingCtx = newContextual[bool]("permissionWithParentsCascading")
+
+	// Relationship Contexts for recovery_code
+	recoveryCodeWithParentsCascadingCtx = newContextual[bool]("recoveryCodeWithParentsCascading")
+
+	// Relationship Contexts for refresh_token
+	refreshTokenWithParentsCascadingCtx = newContextual[bool]("refreshTokenWithParentsCascading")
+
+	// Relationship Contexts for role_permission
+	rolePermissionWithParentsCascadingCtx = newContextual[bool]("rolePermissionWithParentsCascading")
 
 	// Relationship Contexts for user
 	userWithParentsCascadingCtx = newContextual[bool]("userWithParentsCascading")
 
 	// Relationship Contexts for user_auth
 	userAuthWithParentsCascadingCtx = newContextual[bool]("userAuthWithParentsCascading")
+
+	// Relationship Contexts for verification_code
+	verificationCodeWithParentsCascadingCtx = newContextual[bool]("verificationCodeWithParentsCascading")
 )
 
 // Contextual is a convienience wrapper around context.WithValue and context.Value
@@ -6,14 +6,119 @@ package factory
 import "context"
 
 type Factory struct {
-	baseUserMods     UserModSlice
-	baseUserAuthMods UserAuthModSlice
+	baseAPIKeyMods           APIKeyModSlice
+	baseAuditLogMods         AuditLogModSlice
+	baseLoginAttemptMods     LoginAttemptModSlice
+	baseOauthStateMods       OauthStateModSlice
+	basePermissionMods       PermissionModSlice
+	baseRecoveryCodeMods     RecoveryCodeModSlice
+	baseRefreshTokenMods     RefreshTokenModSlice
+	baseRolePermissionMods   RolePermissionModSlice
+	baseUserMods             UserModSlice
+	baseUserAuthMods         UserAuthModSlice
+	baseVerificationCodeMods VerificationCodeModSlice
 }
 
 func New() *Factory {
 	return &Factory{}
 }
 
+func (f *Factory) NewAPIKey(ctx context.Context, mods ...APIKeyMod) *APIKeyTemplate {
+	o := &APIKeyTemplate{f: f}
+
+	if f != nil {
+		f.baseAPIKeyMods.Apply(ctx, o)
+	}
+
+	APIKeyModSlice(mods).Apply(ctx, o)
+
+	return o
+}
+
+func (f *Factory) NewAuditLog(ctx context.Context, mods ...AuditLogMod) *AuditLogTemplate {
+	o := &AuditLogTemplate{f: f}
+
+	if f != nil {
+		f.baseAuditLogMods.Apply(ctx, o)
+	}
+
+	AuditLogModSlice(mods).Apply(ctx, o)
+
+	return o
+}
+
+func (f *Factory) NewLoginAttempt(ctx context.Context, mods ...LoginAttemptMod) *LoginAttemptTemplate {
+	o := &LoginAttemptTemplate{f: f}
+
+	if f != nil {
+		f.baseLoginAttemptMods.Apply(ctx, o)
+	}
+
+	LoginAttemptModSlice(mods).Apply(ctx, o)
+
+	return o
+}
+
+func (f *Factory) NewOauthState(ctx context.Context, mods ...OauthStateMod) *OauthStateTemplate {
+	o := &OauthStateTemplate{f: f}
+
+	if f != nil {
+		f.baseOauthStateMods.Apply(ctx, o)
+	}
+
+	OauthStateModSlice(mods).Apply(ctx, o)
+
+	return o
+}
+
+func (f *Factory) NewPermission(ctx context.Context, mods ...PermissionMod) *PermissionTemplate {
+	o := &PermissionTemplate{f: f}
+
+	if f != nil {
+		f.basePermissionMods.Apply(ctx, o)
+	}
+
+	PermissionModSlice(mods).Apply(ctx, o)
+
+	return o
+}
+
+func (f *Factory) NewRecoveryCode(ctx context.Context, mods ...RecoveryCodeMod) *RecoveryCodeTemplate {
+	o := &RecoveryCodeTemplate{f: f}
+
+	if f != nil {
+		f.baseRecoveryCodeMods.Apply(ctx, o)
+	}
+
+	RecoveryCodeModSlice(mods).Apply(ctx, o)
+
+	return o
+}
+
+func (f *Factory) NewRefreshToken(ctx context.Context, mods ...RefreshTokenMod) *RefreshTokenTemplate {
+	o := &RefreshTokenTemplate{f: f}
+
+	if f != nil {
+		f.baseRefreshTokenMods.Apply(ctx, o)
+	}
+
+	RefreshTokenModSlice(mods).Apply(ctx, o)
+
+	return o
+}
+
+func (f *Factory) NewRolePermission(ctx context.Context, mods ...RolePermissionMod) *RolePermissionTemplate {
+	o := &RolePermissionTemplate{f: f}
+
+	if f != nil {
+		f.baseRolePermissionMods.Apply(ctx, o)
+	}
+
+	RolePermissionModSlice(mods).Apply(ctx, o)
+
+	return o
+}
+
 func (f *Factory) NewUser(ctx context.Context, mods ...UserMod) *UserTemplate {
 	o := &UserTemplate{f: f}
 
@@ -38,6 +143,82 @@ func (f *Factory) NewUserAuth(ctx context.Context, mods ...UserAuthMod) *UserAut
 	return o
 }
 
+func (f *Factory) NewVerificationCode(ctx context.Context, mods ...VerificationCodeMod) *VerificationCodeTemplate {
+	o := &VerificationCodeTemplate{f: f}
+
+	if f != nil {
+		f.baseVerificationCodeMods.Apply(ctx, o)
+	}
+
+	VerificationCodeModSlice(mods).Apply(ctx, o)
+
+	return o
+}
+
+func (f *Factory) ClearBaseAPIKeyMods() {
+	f.baseAPIKeyMods = nil
+}
+
+func (f *Factory) AddBaseAPIKeyMod(mods ...APIKeyMod) {
+	f.baseAPIKeyMods = append(f.baseAPIKeyMods, mods...)
+}
+
+func (f *Factory) ClearBaseAuditLogMods() {
+	f.baseAuditLogMods = nil
+}
+
+func (f *Factory) AddBaseAuditLogMod(mods ...AuditLogMod) {
+	f.baseAuditLogMods = append(f.baseAuditLogMods, mods...)
+}
+
+func (f *Factory) ClearBaseLoginAttemptMods() {
+	f.baseLoginAttemptMods = nil
+}
+
+func (f *Factory) AddBaseLoginAttemptMod(mods ...LoginAttemptMod) {
+	f.baseLoginAttemptMods = append(f.baseLoginAttemptMods, mods...)
+}
+
+func (f *Factory) ClearBaseOauthStateMods() {
+	f.baseOauthStateMods = nil
+}
+
+func (f *Factory) AddBaseOauthStateMod(mods ...OauthStateMod) {
+	f.baseOauthStateMods = append(f.baseOauthStateMods, mods...)
+}
+
+func (f *Factory) ClearBasePermissionMods() {
+	f.basePermissionMods = nil
+}
+
+func (f *Factory) AddBasePermissionMod(mods ...PermissionMod) {
+	f.basePermissionMods = append(f.basePermissionMods, mods...)
+}
+
+func (f *Factory) ClearBaseRecoveryCodeMods() {
+	f.baseRecoveryCodeMods = nil
+}
+
+func (f *Factory) AddBaseRecoveryCodeMod(mods ...RecoveryCodeMod) {
+	f.baseRecoveryCodeMods = append(f.baseRecoveryCodeMods, mods...)
+}
+
+func (f *Factory) ClearBaseRefreshTokenMods() {
+	f.baseRefreshTokenMods = nil
+}
+
+func (f *Factory) AddBaseRefreshTokenMod(mods ...RefreshTokenMod) {
+	f.baseRefreshTokenMods = append(f.baseRefreshTokenMods, mods...)
+}
+
+func (f *Factory) ClearBaseRolePermissionMods() {
+	f.baseRolePermissionMods = nil
+}
+
+func (f *Factory) AddBaseRolePermissionMod(mods ...RolePermissionMod) {
+	f.baseRolePermissionMods = append(f.baseRolePermissionMods, mods...)
+}
+
 func (f *Factory) ClearBaseUserMods() {
 	f.baseUserMods = nil
 }
@@ -53,3 +234,11 @@ func (f *Factory) ClearBaseUserAuthMods() {
 func (f *Factory) AddBaseUserAuthMod(mods ...UserAuthMod) {
 	f.baseUserAuthMods = append(f.baseUserAuthMods, mods...)
 }
+
+func (f *Factory) ClearBaseVerificationCodeMods() {
+	f.baseVerificationCodeMods = nil
+}
+
+func (f *Factory) AddBaseVerificationCodeMod(mods ...VerificationCodeMod) {
+	f.baseVerificationCodeMods = append(f.baseVerificationCodeMods, mods...)
+}
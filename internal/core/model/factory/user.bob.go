@@ -35,15 +35,16 @@ func (mods UserModSlice) Apply(ctx context.Context, n *UserTemplate) {
 // UserTemplate is an object representing the database table.
 // all columns are optional and should be set by mods
 type UserTemplate struct {
-	ID         func() int64
-	CreateTime func() time.Time
-	UpdateTime func() time.Time
-	DeleteTime func() time.Time
-	DelState   func() int64
-	Version    func() int64
-	Nickname   func() string
-	Info       func() string
-	Role       func() string
+	ID              func() int64
+	CreateTime      func() time.Time
+	UpdateTime      func() time.Time
+	DeleteTime      func() time.Time
+	DelState        func() int64
+	Version         func() int64
+	Nickname        func() string
+	Info            func() string
+	Role            func() string
+	TokenGeneration func() int64
 
 	f *Factory
 }
@@ -100,6 +101,10 @@ func (o UserTemplate) BuildSetter() *models.UserSetter {
 		val := o.Role()
 		m.Role = &val
 	}
+	if o.TokenGeneration != nil {
+		val := o.TokenGeneration()
+		m.TokenGeneration = &val
+	}
 
 	return m
 }
@@ -149,6 +154,9 @@ func (o UserTemplate) Build() *models.User {
 	if o.Role != nil {
 		m.Role = o.Role()
 	}
+	if o.TokenGeneration != nil {
+		m.TokenGeneration = o.TokenGeneration()
+	}
 
 	o.setModelRels(m)
 
@@ -309,6 +317,7 @@ func (m userMods) RandomizeAllColumns(f *faker.Faker) UserMod {
 		UserMods.RandomNickname(f),
 		UserMods.RandomInfo(f),
 		UserMods.RandomRole(f),
+		UserMods.RandomTokenGeneration(f),
 	}
 }
 
@@ -591,6 +600,37 @@ func (m userMods) RandomRole(f *faker.Faker) UserMod {
 	})
 }
 
+// Set the model columns to this value
+func (m userMods) TokenGeneration(val int64) UserMod {
+	return UserModFunc(func(_ context.Context, o *UserTemplate) {
+		o.TokenGeneration = func() int64 { return val }
+	})
+}
+
+// Set the Column from the function
+func (m userMods) TokenGenerationFunc(f func() int64) UserMod {
+	return UserModFunc(func(_ context.Context, o *UserTemplate) {
+		o.TokenGeneration = f
+	})
+}
+
+// Clear any values for the column
+func (m userMods) UnsetTokenGeneration() UserMod {
+	return UserModFunc(func(_ context.Context, o *UserTemplate) {
+		o.TokenGeneration = nil
+	})
+}
+
+// Generates a random value for the column using the given faker
+// if faker is nil, a default faker is used
+func (m userMods) RandomTokenGeneration(f *faker.Faker) UserMod {
+	return UserModFunc(func(_ context.Context, o *UserTemplate) {
+		o.TokenGeneration = func() int64 {
+			return random_int64(f)
+		}
+	})
+}
+
 func (m userMods) WithParentsCascading() UserMod {
 	return UserModFunc(func(ctx context.Context, o *UserTemplate) {
 		if isDone, _ := userWithParentsCascadingCtx.Value(ctx); isDone {
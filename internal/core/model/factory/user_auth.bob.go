@@ -608,7 +608,7 @@ func (m userAuthMods) UnsetAuthID() UserAuthMod {
 func (m userAuthMods) RandomAuthID(f *faker.Faker) UserAuthMod {
 	return UserAuthModFunc(func(_ context.Context, o *UserAuthTemplate) {
 		o.AuthID = func() string {
-			return random_string(f, "64")
+			return random_string(f, "255")
 		}
 	})
 }
@@ -19,15 +19,16 @@ import (
 
 // User is an object representing the database table.
 type User struct {
-	ID         int64     `db:"id,pk" `
-	CreateTime time.Time `db:"create_time" `
-	UpdateTime time.Time `db:"update_time" `
-	DeleteTime time.Time `db:"delete_time" `
-	DelState   int64     `db:"del_state" `
-	Version    int64     `db:"version" `
-	Nickname   string    `db:"nickname" `
-	Info       string    `db:"info" `
-	Role       string    `db:"role" `
+	ID              int64     `db:"id,pk" `
+	CreateTime      time.Time `db:"create_time" `
+	UpdateTime      time.Time `db:"update_time" `
+	DeleteTime      time.Time `db:"delete_time" `
+	DelState        int64     `db:"del_state" `
+	Version         int64     `db:"version" `
+	Nickname        string    `db:"nickname" `
+	Info            string    `db:"info" `
+	Role            string    `db:"role" `
+	TokenGeneration int64     `db:"token_generation" `
 }
 
 // UserSlice is an alias for a slice of pointers to User.
@@ -41,30 +42,32 @@ var Users = psql.NewTablex[*User, UserSlice, *UserSetter]("", "user")
 type UsersQuery = *psql.ViewQuery[*User, UserSlice]
 
 type userColumnNames struct {
-	ID         string
-	CreateTime string
-	UpdateTime string
-	DeleteTime string
-	DelState   string
-	Version    string
-	Nickname   string
-	Info       string
-	Role       string
+	ID              string
+	CreateTime      string
+	UpdateTime      string
+	DeleteTime      string
+	DelState        string
+	Version         string
+	Nickname        string
+	Info            string
+	Role            string
+	TokenGeneration string
 }
 
 var UserColumns = buildUserColumns("user")
 
 type userColumns struct {
-	tableAlias string
-	ID         psql.Expression
-	CreateTime psql.Expression
-	UpdateTime psql.Expression
-	DeleteTime psql.Expression
-	DelState   psql.Expression
-	Version    psql.Expression
-	Nickname   psql.Expression
-	Info       psql.Expression
-	Role       psql.Expression
+	tableAlias      string
+	ID              psql.Expression
+	CreateTime      psql.Expression
+	UpdateTime      psql.Expression
+	DeleteTime      psql.Expression
+	DelState        psql.Expression
+	Version         psql.Expression
+	Nickname        psql.Expression
+	Info            psql.Expression
+	Role            psql.Expression
+	TokenGeneration psql.Expression
 }
 
 func (c userColumns) Alias() string {
@@ -77,29 +80,31 @@ func (userColumns) AliasedAs(alias string) userColumns {
 
 func buildUserColumns(alias string) userColumns {
 	return userColumns{
-		tableAlias: alias,
-		ID:         psql.Quote(alias, "id"),
-		CreateTime: psql.Quote(alias, "create_time"),
-		UpdateTime: psql.Quote(alias, "update_time"),
-		DeleteTime: psql.Quote(alias, "delete_time"),
-		DelState:   psql.Quote(alias, "del_state"),
-		Version:    psql.Quote(alias, "version"),
-		Nickname:   psql.Quote(alias, "nickname"),
-		Info:       psql.Quote(alias, "info"),
-		Role:       psql.Quote(alias, "role"),
+		tableAlias:      alias,
+		ID:              psql.Quote(alias, "id"),
+		CreateTime:      psql.Quote(alias, "create_time"),
+		UpdateTime:      psql.Quote(alias, "update_time"),
+		DeleteTime:      psql.Quote(alias, "delete_time"),
+		DelState:        psql.Quote(alias, "del_state"),
+		Version:         psql.Quote(alias, "version"),
+		Nickname:        psql.Quote(alias, "nickname"),
+		Info:            psql.Quote(alias, "info"),
+		Role:            psql.Quote(alias, "role"),
+		TokenGeneration: psql.Quote(alias, "token_generation"),
 	}
 }
 
 type userWhere[Q psql.Filterable] struct {
-	ID         psql.WhereMod[Q, int64]
-	CreateTime psql.WhereMod[Q, time.Time]
-	UpdateTime psql.WhereMod[Q, time.Time]
-	DeleteTime psql.WhereMod[Q, time.Time]
-	DelState   psql.WhereMod[Q, int64]
-	Version    psql.WhereMod[Q, int64]
-	Nickname   psql.WhereMod[Q, string]
-	Info       psql.WhereMod[Q, string]
-	Role       psql.WhereMod[Q, string]
+	ID              psql.WhereMod[Q, int64]
+	CreateTime      psql.WhereMod[Q, time.Time]
+	UpdateTime      psql.WhereMod[Q, time.Time]
+	DeleteTime      psql.WhereMod[Q, time.Time]
+	DelState        psql.WhereMod[Q, int64]
+	Version         psql.WhereMod[Q, int64]
+	Nickname        psql.WhereMod[Q, string]
+	Info            psql.WhereMod[Q, string]
+	Role            psql.WhereMod[Q, string]
+	TokenGeneration psql.WhereMod[Q, int64]
 }
 
 func (userWhere[Q]) AliasedAs(alias string) userWhere[Q] {
@@ -108,15 +113,16 @@ func (userWhere[Q]) AliasedAs(alias string) userWhere[Q] {
 
 func buildUserWhere[Q psql.Filterable](cols userColumns) userWhere[Q] {
 	return userWhere[Q]{
-		ID:         psql.Where[Q, int64](cols.ID),
-		CreateTime: psql.Where[Q, time.Time](cols.CreateTime),
-		UpdateTime: psql.Where[Q, time.Time](cols.UpdateTime),
-		DeleteTime: psql.Where[Q, time.Time](cols.DeleteTime),
-		DelState:   psql.Where[Q, int64](cols.DelState),
-		Version:    psql.Where[Q, int64](cols.Version),
-		Nickname:   psql.Where[Q, string](cols.Nickname),
-		Info:       psql.Where[Q, string](cols.Info),
-		Role:       psql.Where[Q, string](cols.Role),
+		ID:              psql.Where[Q, int64](cols.ID),
+		CreateTime:      psql.Where[Q, time.Time](cols.CreateTime),
+		UpdateTime:      psql.Where[Q, time.Time](cols.UpdateTime),
+		DeleteTime:      psql.Where[Q, time.Time](cols.DeleteTime),
+		DelState:        psql.Where[Q, int64](cols.DelState),
+		Version:         psql.Where[Q, int64](cols.Version),
+		Nickname:        psql.Where[Q, string](cols.Nickname),
+		Info:            psql.Where[Q, string](cols.Info),
+		Role:            psql.Where[Q, string](cols.Role),
+		TokenGeneration: psql.Where[Q, int64](cols.TokenGeneration),
 	}
 }
 
@@ -137,19 +143,20 @@ type userErrors struct {
 // All values are optional, and do not have to be set
 // Generated columns are not included
 type UserSetter struct {
-	ID         *int64     `db:"id,pk" `
-	CreateTime *time.Time `db:"create_time" `
-	UpdateTime *time.Time `db:"update_time" `
-	DeleteTime *time.Time `db:"delete_time" `
-	DelState   *int64     `db:"del_state" `
-	Version    *int64     `db:"version" `
-	Nickname   *string    `db:"nickname" `
-	Info       *string    `db:"info" `
-	Role       *string    `db:"role" `
+	ID              *int64     `db:"id,pk" `
+	CreateTime      *time.Time `db:"create_time" `
+	UpdateTime      *time.Time `db:"update_time" `
+	DeleteTime      *time.Time `db:"delete_time" `
+	DelState        *int64     `db:"del_state" `
+	Version         *int64     `db:"version" `
+	Nickname        *string    `db:"nickname" `
+	Info            *string    `db:"info" `
+	Role            *string    `db:"role" `
+	TokenGeneration *int64     `db:"token_generation" `
 }
 
 func (s UserSetter) SetColumns() []string {
-	vals := make([]string, 0, 9)
+	vals := make([]string, 0, 10)
 	if s.ID != nil {
 		vals = append(vals, "id")
 	}
@@ -186,6 +193,10 @@ func (s UserSetter) SetColumns() []string {
 		vals = append(vals, "role")
 	}
 
+	if s.TokenGeneration != nil {
+		vals = append(vals, "token_generation")
+	}
+
 	return vals
 }
 
@@ -217,6 +228,9 @@ func (s UserSetter) Overwrite(t *User) {
 	if s.Role != nil {
 		t.Role = *s.Role
 	}
+	if s.TokenGeneration != nil {
+		t.TokenGeneration = *s.TokenGeneration
+	}
 }
 
 func (s *UserSetter) Apply(q *dialect.InsertQuery) {
@@ -225,7 +239,7 @@ func (s *UserSetter) Apply(q *dialect.InsertQuery) {
 	})
 
 	q.AppendValues(bob.ExpressionFunc(func(ctx context.Context, w io.Writer, d bob.Dialect, start int) ([]any, error) {
-		vals := make([]bob.Expression, 9)
+		vals := make([]bob.Expression, 10)
 		if s.ID != nil {
 			vals[0] = psql.Arg(*s.ID)
 		} else {
@@ -280,6 +294,12 @@ func (s *UserSetter) Apply(q *dialect.InsertQuery) {
 			vals[8] = psql.Raw("DEFAULT")
 		}
 
+		if s.TokenGeneration != nil {
+			vals[9] = psql.Arg(*s.TokenGeneration)
+		} else {
+			vals[9] = psql.Raw("DEFAULT")
+		}
+
 		return bob.ExpressSlice(ctx, w, d, start, vals, "", ", ", "")
 	}))
 }
@@ -289,7 +309,7 @@ func (s UserSetter) UpdateMod() bob.Mod[*dialect.UpdateQuery] {
 }
 
 func (s UserSetter) Expressions(prefix ...string) []bob.Expression {
-	exprs := make([]bob.Expression, 0, 9)
+	exprs := make([]bob.Expression, 0, 10)
 
 	if s.ID != nil {
 		exprs = append(exprs, expr.Join{Sep: " = ", Exprs: []bob.Expression{
@@ -354,6 +374,13 @@ func (s UserSetter) Expressions(prefix ...string) []bob.Expression {
 		}})
 	}
 
+	if s.TokenGeneration != nil {
+		exprs = append(exprs, expr.Join{Sep: " = ", Exprs: []bob.Expression{
+			psql.Quote(append(prefix, "token_generation")...),
+			psql.Arg(s.TokenGeneration),
+		}})
+	}
+
 	return exprs
 }
 
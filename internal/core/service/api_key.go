@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+)
+
+const (
+	// api key format: ak_<prefix>_<secret>, the prefix identifies the key
+	apiKeyType        = "ak"
+	apiKeyPrefixBytes = 6
+	apiKeySecretBytes = 32
+	apiKeyNameMaxLen  = 64
+	// last_used_time is updated at most once per interval, so a busy key does not write on every request
+	apiKeyLastUsedUpdateInterval = time.Minute
+)
+
+// APIKeyService manages the api keys of machine callers, a key is granted a set of permissions as scopes
+type APIKeyService struct {
+	sidecar       *base.CustomSidecar
+	sqlConnector  *dao.SQLConnector
+	db            bob.Executor
+	permissionSrv *PermissionService
+	auditSrv      *AuditService
+}
+
+func NewAPIKeyService(sidecar *base.CustomSidecar, sqlConnector *dao.SQLConnector, permissionSrv *PermissionService, auditSrv *AuditService) (*APIKeyService, error) {
+	return &APIKeyService{
+		sidecar:       sidecar,
+		sqlConnector:  sqlConnector,
+		db:            sqlConnector.Executor,
+		permissionSrv: permissionSrv,
+		auditSrv:      auditSrv,
+	}, nil
+}
+
+// CreateAPIKey returns the new key and its plaintext, the plaintext is not stored and can not be read again,
+// zero expireTime means the key never expires.
+// The scopes must be granted to the role of the creator, creatorID 0 is the local cli which may grant any scope
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, name string, scopes []string, expireTime time.Time, creatorID int64) (*model.APIKey, string, error) {
+	if name == "" || len(name) > apiKeyNameMaxLen {
+		return nil, "", cerrcode.ErrRequestParameter.Wrap("name is empty or too long")
+	}
+	if !expireTime.IsZero() && expireTime.Before(time.Now().UTC()) {
+		return nil, "", cerrcode.ErrRequestParameter.Wrap("expire time is in the past")
+	}
+	scopes = lo.Uniq(scopes)
+	sort.Strings(scopes)
+	if len(scopes) == 0 {
+		return nil, "", cerrcode.ErrRequestParameter.Wrap("scopes is empty")
+	}
+	permissions, err := model.Permissions.Query(
+		model.SelectWhere.Permissions.Name.In(scopes...),
+		model.SelectWhere.Permissions.DelState.EQ(entity.DelStateActive),
+	).All(ctx, s.db)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to query permissions")
+	}
+	if len(permissions) != len(scopes) {
+		unknown, _ := lo.Difference(scopes, lo.Map(permissions, func(item *model.Permission, _ int) string {
+			return item.Name
+		}))
+		return nil, "", cerrcode.ErrRequestParameter.Wrap("unknown scopes: " + strings.Join(unknown, ","))
+	}
+	if creatorID != 0 {
+		creator, err := model.Users.Query(
+			model.SelectWhere.Users.ID.EQ(creatorID),
+			model.SelectWhere.Users.DelState.EQ(entity.DelStateActive),
+		).One(ctx, s.db)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, "", cerrcode.ErrPermissionDenied.Wrap("creator not found")
+			}
+			return nil, "", errors.Wrap(err, "failed to query creator")
+		}
+		// a key can not be granted more than its creator owns
+		denied := lo.Reject(scopes, func(item string, _ int) bool {
+			return s.permissionSrv.HasPermission(creator.Role, item)
+		})
+		if len(denied) != 0 {
+			return nil, "", cerrcode.ErrPermissionDenied.Wrap("scopes not granted to the creator: " + strings.Join(denied, ","))
+		}
+	}
+
+	prefixRaw := make([]byte, apiKeyPrefixBytes)
+	secretRaw := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(prefixRaw); err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate api key")
+	}
+	if _, err := rand.Read(secretRaw); err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate api key")
+	}
+	prefix := hex.EncodeToString(prefixRaw)
+	key := apiKeyType + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(secretRaw)
+
+	var apiKey *model.APIKey
+	err = s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		var err error
+		apiKey, err = model.APIKeys.Insert(&model.APIKeySetter{
+			Name:         lo.ToPtr(name),
+			Prefix:       lo.ToPtr(prefix),
+			KeyHash:      lo.ToPtr(hashSecret(key)),
+			Scopes:       lo.ToPtr(strings.Join(scopes, ",")),
+			CreatorID:    lo.ToPtr(creatorID),
+			ExpireTime:   lo.ToPtr(expireTime),
+			LastUsedTime: lo.ToPtr(time.Time{}),
+		}).One(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert api key")
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionAPIKeyCreate,
+			Target: entity.AuditTargetAPIKey(apiKey.ID),
+			After:  map[string]any{"name": name, "scopes": scopes, "creator_id": creatorID},
+		})
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return apiKey, key, nil
+}
+
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) (model.APIKeySlice, error) {
+	apiKeys, err := model.APIKeys.Query(
+		model.SelectWhere.APIKeys.DelState.EQ(entity.DelStateActive),
+		sm.OrderBy(model.APIKeyColumns.CreateTime),
+	).All(ctx, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query api keys")
+	}
+	return apiKeys, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id int64) error {
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		now := time.Now().UTC()
+		revoked, err := model.APIKeys.Update(
+			model.APIKeySetter{
+				DeleteTime: lo.ToPtr(now),
+				DelState:   lo.ToPtr(entity.DelStateDeleted),
+			}.UpdateMod(),
+			model.UpdateWhere.APIKeys.ID.EQ(id),
+			model.UpdateWhere.APIKeys.DelState.EQ(entity.DelStateActive),
+		).Exec(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to revoke api key")
+		}
+		if revoked == 0 {
+			return cerrcode.ErrRequestParameter.Wrap("api key not found")
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionAPIKeyRevoke,
+			Target: entity.AuditTargetAPIKey(id),
+			Before: map[string]any{"del_state": entity.DelStateActive},
+			After:  map[string]any{"del_state": entity.DelStateDeleted},
+		})
+	})
+}
+
+// revokeAPIKeysOfCreator revokes the keys created by the user, so they do not outlive the user
+func revokeAPIKeysOfCreator(ctx context.Context, exec bob.Executor, creatorID int64, revokeTime time.Time) error {
+	if _, err := model.APIKeys.Update(
+		model.APIKeySetter{
+			DeleteTime: lo.ToPtr(revokeTime),
+			DelState:   lo.ToPtr(entity.DelStateDeleted),
+		}.UpdateMod(),
+		model.UpdateWhere.APIKeys.CreatorID.EQ(creatorID),
+		model.UpdateWhere.APIKeys.DelState.EQ(entity.DelStateActive),
+	).Exec(ctx, exec); err != nil {
+		return errors.Wrap(err, "failed to revoke api keys of creator")
+	}
+	return nil
+}
+
+// Verify returns the active and unexpired api key matching the plaintext
+func (s *APIKeyService) Verify(ctx context.Context, key string) (*model.APIKey, error) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyType {
+		return nil, cerrcode.ErrAuthCode.Wrap("api key format invalid")
+	}
+	apiKey, err := model.APIKeys.Query(
+		model.SelectWhere.APIKeys.Prefix.EQ(parts[1]),
+		model.SelectWhere.APIKeys.DelState.EQ(entity.DelStateActive),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, cerrcode.ErrAuthCode.Wrap("api key not found")
+		}
+		return nil, errors.Wrap(err, "failed to query api key")
+	}
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashSecret(key))) != 1 {
+		return nil, cerrcode.ErrAuthCode.Wrap("api key mismatch")
+	}
+	now := time.Now().UTC()
+	if !apiKey.ExpireTime.IsZero() && now.After(apiKey.ExpireTime) {
+		return nil, cerrcode.ErrAuthCode.Wrap("api key expired")
+	}
+
+	if now.Sub(apiKey.LastUsedTime) > apiKeyLastUsedUpdateInterval {
+		if _, err := model.APIKeys.Update(
+			model.APIKeySetter{LastUsedTime: lo.ToPtr(now)}.UpdateMod(),
+			model.UpdateWhere.APIKeys.ID.EQ(apiKey.ID),
+		).Exec(ctx, s.db); err != nil {
+			return nil, errors.Wrap(err, "failed to update api key last used time")
+		}
+		apiKey.LastUsedTime = now
+	}
+	return apiKey, nil
+}
+
+// Scopes returns the permissions granted to the api key
+func (s *APIKeyService) Scopes(apiKey *model.APIKey) []string {
+	if apiKey.Scopes == "" {
+		return nil
+	}
+	return strings.Split(apiKey.Scopes, ",")
+}
+
+func (s *APIKeyService) HasScope(apiKey *model.APIKey, permission string) bool {
+	return lo.Contains(s.Scopes(apiKey), permission)
+}
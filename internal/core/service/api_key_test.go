@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestAPIKeyService(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	permissionSrv, err := NewPermissionService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	require.Nil(t, permissionSrv.Start())
+	apiKeySrv, err := NewAPIKeyService(sidecar, sqlConnector, permissionSrv, auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	_, _, err = apiKeySrv.CreateAPIKey(ctx.Ctx, "ci", []string{"unknown:read"}, time.Time{}, 0)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+	_, _, err = apiKeySrv.CreateAPIKey(ctx.Ctx, "ci", []string{entity.PermissionUserRead}, time.Now().Add(-time.Minute), 0)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	// the creator can only grant the permissions of its role
+	operator, err := model.Users.Insert(&model.UserSetter{Role: lo.ToPtr("operator")}).One(ctx.Ctx, sqlConnector.Executor)
+	require.Nil(t, err)
+	require.Nil(t, permissionSrv.GrantRolePermission(ctx.Ctx, "operator", entity.PermissionUserRead))
+	require.Nil(t, permissionSrv.GrantRolePermission(ctx.Ctx, "operator", entity.PermissionAPIKeyWrite))
+	_, _, err = apiKeySrv.CreateAPIKey(ctx.Ctx, "ci", []string{entity.PermissionUserRead, entity.PermissionRoleWrite}, time.Time{}, operator.ID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrPermissionDenied), errcode.DecodeError(err))
+	_, _, err = apiKeySrv.CreateAPIKey(ctx.Ctx, "ci", []string{entity.PermissionUserRead}, time.Time{}, operator.ID+1)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrPermissionDenied), errcode.DecodeError(err))
+	admin, err := model.Users.Insert(&model.UserSetter{Role: lo.ToPtr(entity.UserRoleAdmin)}).One(ctx.Ctx, sqlConnector.Executor)
+	require.Nil(t, err)
+	_, _, err = apiKeySrv.CreateAPIKey(ctx.Ctx, "admin", []string{entity.PermissionRoleWrite}, time.Time{}, admin.ID)
+	require.Nil(t, err)
+	require.Nil(t, apiKeySrv.RevokeAPIKey(ctx.Ctx, lo.Must(apiKeySrv.ListAPIKeys(ctx.Ctx))[0].ID))
+
+	apiKey, key, err := apiKeySrv.CreateAPIKey(ctx.Ctx, "ci", []string{entity.PermissionUserRead, entity.PermissionUserRead}, time.Time{}, operator.ID)
+	require.Nil(t, err)
+	require.Equal(t, []string{entity.PermissionUserRead}, apiKeySrv.Scopes(apiKey))
+
+	verified, err := apiKeySrv.Verify(ctx.Ctx, key)
+	require.Nil(t, err)
+	require.Equal(t, apiKey.ID, verified.ID)
+	require.False(t, verified.LastUsedTime.IsZero())
+	require.True(t, apiKeySrv.HasScope(verified, entity.PermissionUserRead))
+	require.False(t, apiKeySrv.HasScope(verified, entity.PermissionUserWrite))
+
+	for _, badKey := range []string{"", "ak_" + apiKey.Prefix, key + "x", "ak_000000000000_" + key[len("ak_"+apiKey.Prefix+"_"):]} {
+		_, err = apiKeySrv.Verify(ctx.Ctx, badKey)
+		require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err), badKey)
+	}
+
+	// expired keys are rejected
+	_, expiringKey, err := apiKeySrv.CreateAPIKey(ctx.Ctx, "short", []string{entity.PermissionUserRead}, time.Now().Add(time.Second), 0)
+	require.Nil(t, err)
+	time.Sleep(1100 * time.Millisecond)
+	_, err = apiKeySrv.Verify(ctx.Ctx, expiringKey)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+
+	apiKeys, err := apiKeySrv.ListAPIKeys(ctx.Ctx)
+	require.Nil(t, err)
+	require.Len(t, apiKeys, 2)
+
+	require.Nil(t, apiKeySrv.RevokeAPIKey(ctx.Ctx, apiKey.ID))
+	err = apiKeySrv.RevokeAPIKey(ctx.Ctx, apiKey.ID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+	_, err = apiKeySrv.Verify(ctx.Ctx, key)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+
+	auditLogs, _, err := auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Target: entity.AuditTargetAPIKey(apiKey.ID)}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 2)
+	require.Equal(t, entity.AuditActionAPIKeyRevoke, auditLogs[0].Action)
+	require.Equal(t, entity.AuditActionAPIKeyCreate, auditLogs[1].Action)
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	glog "github.com/zunkk/go-sidecar/log"
+)
+
+const (
+	auditLogListDefaultLimit = 20
+	auditLogListMaxLimit     = 100
+)
+
+var auditLog = glog.WithModule("audit")
+
+type auditMetaKey struct{}
+
+// AuditMeta is the request info recorded with the audit logs, the api layer attaches it to the context
+type AuditMeta struct {
+	Actor     string
+	ClientIP  string
+	RequestID int64
+}
+
+func WithAuditMeta(ctx context.Context, meta AuditMeta) context.Context {
+	return context.WithValue(ctx, auditMetaKey{}, meta)
+}
+
+// AuditEntry is an operation to record, Before and After are json objects of the changed resource
+type AuditEntry struct {
+	// Actor overrides the actor of the context, e.g. the user logging in
+	Actor  string
+	Action string
+	Target string
+	Before any
+	After  any
+}
+
+// AuditFilter is the condition of ListAuditLogs, zero fields are ignored
+type AuditFilter struct {
+	Actor    string
+	Action   string
+	Target   string
+	TimeFrom time.Time
+	// exclusive
+	TimeTo time.Time
+}
+
+// AuditService records who did what, the logs older than the retention are purged periodically
+type AuditService struct {
+	sidecar *base.CustomSidecar
+	db      bob.Executor
+	readDB  bob.Executor
+}
+
+func NewAuditService(sidecar *base.CustomSidecar, sqlConnector *dao.SQLConnector) (*AuditService, error) {
+	s := &AuditService{
+		sidecar: sidecar,
+		db:      sqlConnector.Executor,
+		readDB:  sqlConnector.ReadExecutor,
+	}
+	sidecar.RegisterLifecycleHook(s)
+	return s, nil
+}
+
+func (s *AuditService) ComponentName() string {
+	return "audit-service"
+}
+
+func (s *AuditService) Start() error {
+	cfg := s.sidecar.Repo.Cfg.Audit
+	if cfg.RetentionDuration == 0 || cfg.PurgeInterval == 0 {
+		return nil
+	}
+	s.sidecar.SafeGoPersistentTask(func() {
+		ticker := time.NewTicker(cfg.PurgeInterval.ToDuration())
+		defer ticker.Stop()
+		for {
+			if _, err := s.Purge(s.sidecar.Ctx, time.Now().UTC().Add(-cfg.RetentionDuration.ToDuration())); err != nil {
+				auditLog.Warn("Failed to purge audit logs", "err", err)
+			}
+			select {
+			case <-s.sidecar.Ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+	return nil
+}
+
+func (s *AuditService) Stop() error {
+	return nil
+}
+
+// Record writes the entry with the executor of the service, it joins the transaction carried by the context if any
+func (s *AuditService) Record(ctx context.Context, entry AuditEntry) error {
+	return s.RecordTx(ctx, s.db, entry)
+}
+
+// RecordTx writes the entry with the exec, so it is committed or rolled back with the operation in a transaction
+func (s *AuditService) RecordTx(ctx context.Context, exec bob.Executor, entry AuditEntry) error {
+	meta, _ := ctx.Value(auditMetaKey{}).(AuditMeta)
+	actor := entry.Actor
+	if actor == "" {
+		actor = meta.Actor
+	}
+	diff, err := auditDiff(entry.Before, entry.After)
+	if err != nil {
+		return err
+	}
+
+	if _, err := model.AuditLogs.Insert(&model.AuditLogSetter{
+		Actor:     lo.ToPtr(actor),
+		Action:    lo.ToPtr(entry.Action),
+		Target:    lo.ToPtr(entry.Target),
+		Diff:      lo.ToPtr(diff),
+		ClientIP:  lo.ToPtr(meta.ClientIP),
+		RequestID: lo.ToPtr(meta.RequestID),
+	}).Exec(ctx, exec); err != nil {
+		return errors.Wrap(err, "failed to insert audit log")
+	}
+	return nil
+}
+
+// ListAuditLogs returns the logs ordered by id descending, cursor is the id of the last log of the previous page
+// and the returned next cursor is 0 on the last page
+func (s *AuditService) ListAuditLogs(ctx context.Context, filter AuditFilter, cursor int64, limit int) (model.AuditLogSlice, int64, error) {
+	if limit <= 0 {
+		limit = auditLogListDefaultLimit
+	}
+	if limit > auditLogListMaxLimit {
+		limit = auditLogListMaxLimit
+	}
+	mods := []bob.Mod[*dialect.SelectQuery]{
+		sm.OrderBy(model.AuditLogColumns.ID).Desc(),
+		sm.Limit(limit + 1),
+	}
+	if cursor != 0 {
+		mods = append(mods, model.SelectWhere.AuditLogs.ID.LT(cursor))
+	}
+	if filter.Actor != "" {
+		mods = append(mods, model.SelectWhere.AuditLogs.Actor.EQ(filter.Actor))
+	}
+	if filter.Action != "" {
+		mods = append(mods, model.SelectWhere.AuditLogs.Action.EQ(filter.Action))
+	}
+	if filter.Target != "" {
+		mods = append(mods, model.SelectWhere.AuditLogs.Target.EQ(filter.Target))
+	}
+	if !filter.TimeFrom.IsZero() {
+		mods = append(mods, model.SelectWhere.AuditLogs.CreateTime.GTE(filter.TimeFrom))
+	}
+	if !filter.TimeTo.IsZero() {
+		mods = append(mods, model.SelectWhere.AuditLogs.CreateTime.LT(filter.TimeTo))
+	}
+	auditLogs, err := model.AuditLogs.Query(mods...).All(ctx, s.readDB)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to query audit logs")
+	}
+	if len(auditLogs) <= limit {
+		return auditLogs, 0, nil
+	}
+	auditLogs = auditLogs[:limit]
+	return auditLogs, auditLogs[limit-1].ID, nil
+}
+
+// Purge deletes the logs created before the time and returns the number of them
+func (s *AuditService) Purge(ctx context.Context, before time.Time) (int64, error) {
+	purged, err := model.AuditLogs.Delete(
+		model.DeleteWhere.AuditLogs.CreateTime.LT(before.UTC()),
+	).Exec(ctx, s.db)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to purge audit logs")
+	}
+	if purged != 0 {
+		auditLog.Info("Audit logs purged", "count", purged, "before", before)
+	}
+	return purged, nil
+}
+
+// auditDiff keeps the fields of before and after that differ
+func auditDiff(before any, after any) (string, error) {
+	if before == nil && after == nil {
+		return "", nil
+	}
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return "", err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return "", err
+	}
+	for key, value := range beforeFields {
+		if afterValue, ok := afterFields[key]; ok && reflect.DeepEqual(value, afterValue) {
+			delete(beforeFields, key)
+			delete(afterFields, key)
+		}
+	}
+	diff, err := json.Marshal(map[string]map[string]any{
+		"before": beforeFields,
+		"after":  afterFields,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal audit diff")
+	}
+	return string(diff), nil
+}
+
+func auditFields(v any) (map[string]any, error) {
+	fields := map[string]any{}
+	if v == nil {
+		return fields, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal audit fields")
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, errors.Wrap(err, "audit fields must be a json object")
+	}
+	return fields, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+)
+
+func TestAuditService(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	userSrv, err := NewUserService(sidecar, sqlConnector, tokenSrv, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	admin, err := authSrv.RegisterByUsername(ctx.Ctx, "admin", "password123", "")
+	require.Nil(t, err)
+	alice, err := authSrv.RegisterByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+
+	loginCtx := WithAuditMeta(ctx.Ctx, AuditMeta{ClientIP: "10.0.0.1", RequestID: 1})
+	_, err = authSrv.LoginByUsername(loginCtx, "alice", "password123", "10.0.0.1")
+	require.Nil(t, err)
+	_, err = authSrv.LoginByUsername(loginCtx, "alice", "wrong-password", "10.0.0.1")
+	require.NotNil(t, err)
+
+	auditLogs, _, err := auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Action: entity.AuditActionLogin}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 1)
+	require.Equal(t, strconv.FormatInt(alice.UserID, 10), auditLogs[0].Actor)
+	require.Equal(t, entity.AuditTargetUser(alice.UserID), auditLogs[0].Target)
+	require.Equal(t, "10.0.0.1", auditLogs[0].ClientIP)
+	require.Equal(t, int64(1), auditLogs[0].RequestID)
+	require.JSONEq(t, `{"before":{},"after":{"auth_type":"username","mfa_pending":false}}`, auditLogs[0].Diff)
+
+	auditLogs, _, err = auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Action: entity.AuditActionLoginFailed}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 1)
+	require.Equal(t, "", auditLogs[0].Actor)
+	require.Equal(t, entity.AuthTypeUsername+":alice", auditLogs[0].Target)
+
+	// the actor of admin operations comes from the request
+	actor := strconv.FormatInt(admin.UserID, 10)
+	adminCtx := WithAuditMeta(ctx.Ctx, AuditMeta{Actor: actor, ClientIP: "10.0.0.2", RequestID: 2})
+	require.Nil(t, userSrv.SetUserRole(adminCtx, actor, alice.UserID, "operator"))
+	require.Nil(t, userSrv.DeleteUser(adminCtx, actor, alice.UserID))
+
+	auditLogs, nextCursor, err := auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Actor: actor}, 0, 1)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 1)
+	require.Equal(t, entity.AuditActionUserDelete, auditLogs[0].Action)
+	require.NotZero(t, nextCursor)
+	auditLogs, nextCursor, err = auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Actor: actor}, nextCursor, 1)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 1)
+	require.Equal(t, entity.AuditActionUserRole, auditLogs[0].Action)
+	require.JSONEq(t, `{"before":{"role":"user"},"after":{"role":"operator"}}`, auditLogs[0].Diff)
+	require.Equal(t, "10.0.0.2", auditLogs[0].ClientIP)
+	require.Zero(t, nextCursor)
+
+	// a failed operation leaves no audit log
+	require.NotNil(t, userSrv.DeleteUser(adminCtx, actor, alice.UserID))
+	auditLogs, _, err = auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Target: entity.AuditTargetUser(alice.UserID), Action: entity.AuditActionUserDelete}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 1)
+
+	purged, err := auditSrv.Purge(ctx.Ctx, time.Now().Add(-time.Hour))
+	require.Nil(t, err)
+	require.Zero(t, purged)
+	purged, err = auditSrv.Purge(ctx.Ctx, time.Now().Add(time.Second))
+	require.Nil(t, err)
+	require.Equal(t, int64(4), purged)
+	auditLogs, _, err = auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{}, 0, 0)
+	require.Nil(t, err)
+	require.Empty(t, auditLogs)
+}
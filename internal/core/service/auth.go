@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-project-startup/internal/pkg/oidc"
+	"github.com/zunkk/go-project-startup/internal/pkg/secretbox"
+)
+
+const (
+	usernameMinLen = 3
+	usernameMaxLen = 64
+	passwordMinLen = 8
+	// bcrypt only uses the first 72 bytes of the password
+	passwordMaxLen = 72
+	// dummyPasswordHash is compared with the password of an unknown account, it has the cost of hashPassword
+	dummyPasswordHash = "$2a$10$2Bm4ZZ3JIoMSGj6rAryKLeWio2yie4JAeszPb4bZ3fHvPvkYUkXdO"
+)
+
+type AuthService struct {
+	sidecar      *base.CustomSidecar
+	sqlConnector *dao.SQLConnector
+	db           bob.Executor
+	tokenSrv     *TokenService
+	auditSrv     *AuditService
+	mailer       mailer.Mailer
+	// secretBox encrypts the totp secrets
+	secretBox *secretbox.Box
+	// now is the clock of totp codes, replaced in tests
+	now func() time.Time
+	// authProviders are the oauth2 providers by name
+	authProviders map[string]oidc.AuthProvider
+}
+
+func NewAuthService(sidecar *base.CustomSidecar, sqlConnector *dao.SQLConnector, tokenSrv *TokenService, mailer mailer.Mailer, auditSrv *AuditService) (*AuthService, error) {
+	key, err := secretbox.LoadKey(sidecar.Repo.Cfg.Auth.SecretEncryptionKey, filepath.Join(sidecar.Repo.RepoPath, secretbox.KeyFileName))
+	if err != nil {
+		return nil, err
+	}
+	secretBox, err := secretbox.New(key)
+	if err != nil {
+		return nil, err
+	}
+	s := &AuthService{
+		sidecar:       sidecar,
+		sqlConnector:  sqlConnector,
+		db:            sqlConnector.Executor,
+		tokenSrv:      tokenSrv,
+		auditSrv:      auditSrv,
+		mailer:        mailer,
+		secretBox:     secretBox,
+		now:           time.Now,
+		authProviders: map[string]oidc.AuthProvider{},
+	}
+	client := &http.Client{Timeout: oidcRequestTimeout}
+	for _, cfg := range sidecar.Repo.Cfg.OIDC.Providers {
+		if err := s.RegisterAuthProvider(oidc.NewProvider(oidc.ProviderConfig{
+			Name:         cfg.Name,
+			Issuer:       cfg.Issuer,
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       cfg.Scopes,
+		}, client)); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// RegisterByUsername creates a user with a username auth and returns the tokens of the new user
+func (s *AuthService) RegisterByUsername(ctx context.Context, username string, password string, nickname string) (*AuthToken, error) {
+	if err := checkUsername(username); err != nil {
+		return nil, err
+	}
+	if nickname == "" {
+		nickname = username
+	}
+	return s.registerWithPassword(ctx, entity.AuthTypeUsername, username, password, nickname)
+}
+
+// LoginByUsername verifies the password of the username auth and starts a new login session,
+// clientIP is empty for ipc calls
+func (s *AuthService) LoginByUsername(ctx context.Context, username string, password string, clientIP string) (*AuthToken, error) {
+	return s.loginWithPassword(ctx, entity.AuthTypeUsername, username, password, clientIP)
+}
+
+func (s *AuthService) registerWithPassword(ctx context.Context, authType string, authID string, password string, nickname string, beforeActions ...dao.DBAction) (*AuthToken, error) {
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	return s.register(ctx, nickname, authType, authID, passwordHash, beforeActions...)
+}
+
+// register creates the user in a transaction and starts a login session, beforeActions run in the same transaction first
+func (s *AuthService) register(ctx context.Context, nickname string, authType string, authID string, authToken string, beforeActions ...dao.DBAction) (*AuthToken, error) {
+	var res *AuthToken
+	err := s.sqlConnector.SubmitDBChangesByTransaction(ctx, append(beforeActions, func(ctx context.Context, dbTX bob.Transaction) error {
+		user, err := s.insertUserWithAuth(ctx, dbTX, entity.UserRoleNormal, nickname, authType, authID, authToken)
+		if err != nil {
+			return err
+		}
+		res, err = s.tokenSrv.Issue(ctx, dbTX, user)
+		return err
+	})...)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *AuthService) loginWithPassword(ctx context.Context, authType string, authID string, password string, clientIP string) (*AuthToken, error) {
+	return s.guardLogin(ctx, authType, authID, clientIP, func() (*AuthToken, error) {
+		userAuth, err := s.findUserAuth(ctx, s.db, authType, authID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// spend the time of a password check, so the response time does not tell whether the account exists
+				_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+				return nil, cerrcode.ErrAccountOrPassword.Wrap("account not found")
+			}
+			return nil, err
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(userAuth.AuthToken), []byte(password)); err != nil {
+			return nil, cerrcode.ErrAccountOrPassword.Wrap("password mismatch")
+		}
+		return s.login(ctx, userAuth)
+	})
+}
+
+// login starts a new login session of the user owning the auth,
+// a user with totp enabled gets a mfa token to finish the login by VerifyMFA instead
+func (s *AuthService) login(ctx context.Context, userAuth *model.UserAuth) (*AuthToken, error) {
+	user, err := model.Users.Query(
+		model.SelectWhere.Users.ID.EQ(userAuth.UserID),
+		model.SelectWhere.Users.DelState.EQ(entity.DelStateActive),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, cerrcode.ErrAccountOrPassword.Wrap("user is deleted")
+		}
+		return nil, errors.Wrap(err, "failed to query user")
+	}
+
+	now := time.Now().UTC()
+	if _, err := model.UserAuths.Update(
+		model.UserAuthSetter{
+			LastLoginTime: lo.ToPtr(now),
+		}.UpdateMod(),
+		model.UpdateWhere.UserAuths.ID.EQ(userAuth.ID),
+	).Exec(ctx, s.db); err != nil {
+		return nil, errors.Wrap(err, "failed to update last login time")
+	}
+
+	totpEnabled, err := model.UserAuths.Query(
+		model.SelectWhere.UserAuths.UserID.EQ(user.ID),
+		model.SelectWhere.UserAuths.AuthType.EQ(entity.AuthTypeTOTP),
+		model.SelectWhere.UserAuths.DelState.EQ(entity.DelStateActive),
+	).Exists(ctx, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query totp auth")
+	}
+	var authToken *AuthToken
+	if totpEnabled {
+		mfaToken, mfaExpireTime, err := s.tokenSrv.GenerateMFAToken(user.ID, user.TokenGeneration)
+		if err != nil {
+			return nil, err
+		}
+		authToken = &AuthToken{
+			UserID:        user.ID,
+			MFAToken:      mfaToken,
+			MFAExpireTime: mfaExpireTime,
+		}
+	} else {
+		authToken, err = s.tokenSrv.Issue(ctx, s.db, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.recordLogin(ctx, user.ID, userAuth.AuthType, totpEnabled); err != nil {
+		return nil, err
+	}
+	return authToken, nil
+}
+
+// recordLogin audits a login, the user is the actor as the caller has not been authenticated
+func (s *AuthService) recordLogin(ctx context.Context, userID int64, authType string, mfaPending bool) error {
+	return s.auditSrv.Record(ctx, AuditEntry{
+		Actor:  strconv.FormatInt(userID, 10),
+		Action: entity.AuditActionLogin,
+		Target: entity.AuditTargetUser(userID),
+		After:  map[string]any{"auth_type": authType, "mfa_pending": mfaPending},
+	})
+}
+
+// findUserAuth returns an error wrapping sql.ErrNoRows if the auth does not exist
+func (s *AuthService) findUserAuth(ctx context.Context, exec bob.Executor, authType string, authID string) (*model.UserAuth, error) {
+	userAuth, err := model.UserAuths.Query(
+		model.SelectWhere.UserAuths.AuthType.EQ(authType),
+		model.SelectWhere.UserAuths.AuthID.EQ(authID),
+		model.SelectWhere.UserAuths.DelState.EQ(entity.DelStateActive),
+	).One(ctx, exec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query user auth")
+	}
+	return userAuth, nil
+}
+
+// insertUserWithAuth creates a user with the role and its first auth
+func (s *AuthService) insertUserWithAuth(ctx context.Context, exec bob.Executor, role string, nickname string, authType string, authID string, authToken string) (*model.User, error) {
+	if err := checkUserAuthNotExists(ctx, exec, authType, authID); err != nil {
+		return nil, err
+	}
+
+	user, err := model.Users.Insert(&model.UserSetter{
+		Nickname: lo.ToPtr(nickname),
+		Role:     lo.ToPtr(role),
+	}).One(ctx, exec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to insert user")
+	}
+
+	if err := s.insertUserAuth(ctx, exec, user.ID, authType, authID, authToken); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *AuthService) insertUserAuth(ctx context.Context, exec bob.Executor, userID int64, authType string, authID string, authToken string) error {
+	now := time.Now().UTC()
+	if _, err := model.UserAuths.Insert(&model.UserAuthSetter{
+		UserID:        lo.ToPtr(userID),
+		AuthType:      lo.ToPtr(authType),
+		AuthID:        lo.ToPtr(authID),
+		AuthToken:     lo.ToPtr(authToken),
+		LastLoginTime: lo.ToPtr(now),
+	}).Exec(ctx, exec); err != nil {
+		return errors.Wrap(err, "failed to insert user auth")
+	}
+	return nil
+}
+
+// checkUserAuthNotExists keeps (auth_type, auth_id) unique across active rows
+func checkUserAuthNotExists(ctx context.Context, exec bob.Executor, authType string, authID string) error {
+	exists, err := model.UserAuths.Query(
+		model.SelectWhere.UserAuths.AuthType.EQ(authType),
+		model.SelectWhere.UserAuths.AuthID.EQ(authID),
+		model.SelectWhere.UserAuths.DelState.EQ(entity.DelStateActive),
+	).Exists(ctx, exec)
+	if err != nil {
+		return errors.Wrap(err, "failed to query user auth")
+	}
+	if exists {
+		return cerrcode.ErrAccountExists.Wrap(authID)
+	}
+	return nil
+}
+
+func checkUsername(username string) error {
+	if len(username) < usernameMinLen || len(username) > usernameMaxLen {
+		return cerrcode.ErrRequestParameter.Wrap(fmt.Sprintf("username length must be between %d and %d", usernameMinLen, usernameMaxLen))
+	}
+	return nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < passwordMinLen || len(password) > passwordMaxLen {
+		return "", cerrcode.ErrRequestParameter.Wrap(fmt.Sprintf("password length must be between %d and %d", passwordMinLen, passwordMaxLen))
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash password")
+	}
+	return string(passwordHash), nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+)
+
+// CreateUserByUsername creates a user with the role and a username auth for the operators, no login session is started
+func (s *AuthService) CreateUserByUsername(ctx context.Context, username string, password string, nickname string, role string) (*model.User, error) {
+	if err := checkUsername(username); err != nil {
+		return nil, err
+	}
+	if role == "" || len(role) > roleMaxLen {
+		return nil, cerrcode.ErrRequestParameter.Wrap("role is empty or too long")
+	}
+	if nickname == "" {
+		nickname = username
+	}
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *model.User
+	err = s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		user, err = s.insertUserWithAuth(ctx, dbTX, role, nickname, entity.AuthTypeUsername, username, passwordHash)
+		if err != nil {
+			return err
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionUserCreate,
+			Target: entity.AuditTargetUser(user.ID),
+			After:  map[string]any{"username": username, "role": role},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ResetPassword sets the password of the username and email auths of the user without the old one,
+// all login sessions are revoked and the failed logins are cleared
+func (s *AuthService) ResetPassword(ctx context.Context, userID int64, password string) error {
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		if err := lockUser(ctx, dbTX, userID); err != nil {
+			return err
+		}
+		updated, err := model.UserAuths.Update(
+			model.UserAuthSetter{
+				AuthToken: lo.ToPtr(passwordHash),
+			}.UpdateMod(),
+			model.UpdateWhere.UserAuths.UserID.EQ(userID),
+			model.UpdateWhere.UserAuths.AuthType.In(entity.AuthTypeUsername, entity.AuthTypeEmail),
+			model.UpdateWhere.UserAuths.DelState.EQ(entity.DelStateActive),
+		).Exec(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to update password")
+		}
+		if updated == 0 {
+			return cerrcode.ErrRequestParameter.Wrap("user has no password login")
+		}
+		if err := s.tokenSrv.RevokeAll(ctx, dbTX, userID); err != nil {
+			return err
+		}
+		// joins the transaction by the context
+		if err := s.clearUserLoginFailures(ctx, userID); err != nil {
+			return err
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionUserPassword,
+			Target: entity.AuditTargetUser(userID),
+		})
+	})
+}
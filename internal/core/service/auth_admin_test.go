@@ -0,0 +1,64 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestAuthService_CreateUserAndResetPassword(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	sidecar.Repo.Cfg.Auth.LoginMaxFailures = 3
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	cliCtx := WithAuditMeta(ctx.Ctx, AuditMeta{Actor: entity.AuditActorCLI})
+	admin, err := authSrv.CreateUserByUsername(cliCtx, "root", "password123", "", entity.UserRoleAdmin)
+	require.Nil(t, err)
+	require.Equal(t, entity.UserRoleAdmin, admin.Role)
+	require.Equal(t, "root", admin.Nickname)
+	_, err = authSrv.CreateUserByUsername(cliCtx, "root", "password123", "", entity.UserRoleAdmin)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountExists), errcode.DecodeError(err))
+	_, err = authSrv.CreateUserByUsername(cliCtx, "root2", "password123", "", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	login, err := authSrv.LoginByUsername(ctx.Ctx, "root", "password123", "")
+	require.Nil(t, err)
+	claims, err := tokenSrv.Verify(ctx.Ctx, login.Token)
+	require.Nil(t, err)
+	require.Equal(t, entity.UserRoleAdmin, claims.Role)
+
+	// the reset works on a locked user and signs out the old sessions
+	for i := 0; i < 3; i++ {
+		_, err = authSrv.LoginByUsername(ctx.Ctx, "root", "wrong-password", "10.0.0.1")
+		require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountOrPassword), errcode.DecodeError(err))
+	}
+	require.Nil(t, authSrv.ResetPassword(cliCtx, admin.ID, "new-password"))
+	_, err = tokenSrv.Verify(ctx.Ctx, login.Token)
+	require.NotNil(t, err)
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "root", "password123", "10.0.0.1")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountOrPassword), errcode.DecodeError(err))
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "root", "new-password", "10.0.0.1")
+	require.Nil(t, err)
+
+	err = authSrv.ResetPassword(cliCtx, admin.ID+1, "new-password")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	auditLogs, _, err := auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Actor: entity.AuditActorCLI}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 2)
+	require.Equal(t, entity.AuditActionUserPassword, auditLogs[0].Action)
+	require.Equal(t, entity.AuditActionUserCreate, auditLogs[1].Action)
+	require.JSONEq(t, `{"before":{},"after":{"username":"root","role":"admin"}}`, auditLogs[1].Diff)
+}
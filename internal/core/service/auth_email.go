@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/repo"
+)
+
+const (
+	// user_auth.auth_id is varchar(255) since migration 000009, emails keep the former limit of 64
+	// so that rolling back that migration, which narrows auth_id to varchar(64) again, still works
+	emailMaxLen                 = 64
+	verificationCodeLen         = 6
+	verificationCodeMaxAttempts = 5
+	resetPasswordTokenBytes     = 32
+)
+
+// SendEmailCode sends a one-time code to the email for registering, logging in or linking to a user.
+// The result does not reveal whether the email is registered, if the purpose does not fit the account state,
+// a notice is sent instead of the code
+func (s *AuthService) SendEmailCode(ctx context.Context, email string, purpose string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	_, err = s.findUserAuth(ctx, s.db, entity.AuthTypeEmail, email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	accountExists := err == nil
+	var notice string
+	switch purpose {
+	case entity.VerificationPurposeRegister, entity.VerificationPurposeLink:
+		if accountExists {
+			notice = "This email is already registered, you can log in with it or reset the password.\n"
+		}
+	case entity.VerificationPurposeLogin:
+		if !accountExists {
+			notice = "No account uses this email, you can register with it.\n"
+		}
+	default:
+		return cerrcode.ErrRequestParameter.Wrap("unsupported purpose: " + purpose)
+	}
+
+	// the code is created even if only the notice is sent, so the resend throttle behaves the same in both cases
+	code, err := randomDigits(verificationCodeLen)
+	if err != nil {
+		return err
+	}
+	if err := s.createVerificationCode(ctx, purpose, email, code); err != nil {
+		return err
+	}
+	if notice != "" {
+		return s.mailer.Send(ctx, mailer.Mail{
+			To:      email,
+			Subject: fmt.Sprintf("[%s] Account notice", repo.AppName),
+			Body:    notice,
+		})
+	}
+	return s.mailer.Send(ctx, mailer.Mail{
+		To:      email,
+		Subject: fmt.Sprintf("[%s] Verification code", repo.AppName),
+		Body:    fmt.Sprintf("Your verification code is %s, it expires in %s.\n", code, s.sidecar.Repo.Cfg.Mail.CodeValidDuration.ToDuration()),
+	})
+}
+
+// RegisterByEmail creates a user with an email auth after the register code is verified
+func (s *AuthService) RegisterByEmail(ctx context.Context, email string, code string, password string, nickname string) (*AuthToken, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	verificationCode, err := s.checkVerificationCode(ctx, entity.VerificationPurposeRegister, email, code)
+	if err != nil {
+		return nil, err
+	}
+	if nickname == "" {
+		nickname = email[:strings.Index(email, "@")]
+	}
+	return s.registerWithPassword(ctx, entity.AuthTypeEmail, email, password, nickname, func(ctx context.Context, dbTX bob.Transaction) error {
+		return s.useVerificationCode(ctx, dbTX, verificationCode)
+	})
+}
+
+// LoginByEmail verifies the password of the email auth and starts a new login session
+func (s *AuthService) LoginByEmail(ctx context.Context, email string, password string, clientIP string) (*AuthToken, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return s.loginWithPassword(ctx, entity.AuthTypeEmail, email, password, clientIP)
+}
+
+// LoginByEmailCode verifies the login code sent to the email and starts a new login session
+func (s *AuthService) LoginByEmailCode(ctx context.Context, email string, code string, clientIP string) (*AuthToken, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return s.guardLogin(ctx, entity.AuthTypeEmail, email, clientIP, func() (*AuthToken, error) {
+		verificationCode, err := s.checkVerificationCode(ctx, entity.VerificationPurposeLogin, email, code)
+		if err != nil {
+			return nil, err
+		}
+		userAuth, err := s.findUserAuth(ctx, s.db, entity.AuthTypeEmail, email)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, cerrcode.ErrAccountOrPassword.Wrap("account not found")
+			}
+			return nil, err
+		}
+		if err := s.useVerificationCode(ctx, s.db, verificationCode); err != nil {
+			return nil, err
+		}
+		return s.login(ctx, userAuth)
+	})
+}
+
+// SendPasswordResetEmail sends a password reset link to the email,
+// nothing is sent if the email is not registered, and no error is returned to avoid leaking it
+func (s *AuthService) SendPasswordResetEmail(ctx context.Context, email string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	if _, err := s.findUserAuth(ctx, s.db, entity.AuthTypeEmail, email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	tokenRaw := make([]byte, resetPasswordTokenBytes)
+	if _, err := rand.Read(tokenRaw); err != nil {
+		return errors.Wrap(err, "failed to generate reset password token")
+	}
+	token := base64.RawURLEncoding.EncodeToString(tokenRaw)
+	if err := s.createVerificationCode(ctx, entity.VerificationPurposeResetPassword, email, token); err != nil {
+		return err
+	}
+
+	link := s.sidecar.Repo.Cfg.Mail.ResetPasswordURL + "?" + url.Values{
+		"email": []string{email},
+		"token": []string{token},
+	}.Encode()
+	return s.mailer.Send(ctx, mailer.Mail{
+		To:      email,
+		Subject: fmt.Sprintf("[%s] Reset password", repo.AppName),
+		Body:    fmt.Sprintf("Open the link to reset your password, it expires in %s:\n%s\n", s.sidecar.Repo.Cfg.Mail.CodeValidDuration.ToDuration(), link),
+	})
+}
+
+// ResetPasswordByEmail sets a new password with the token of the reset link, all login sessions are revoked
+func (s *AuthService) ResetPasswordByEmail(ctx context.Context, email string, token string, password string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	verificationCode, err := s.checkVerificationCode(ctx, entity.VerificationPurposeResetPassword, email, token)
+	if err != nil {
+		return err
+	}
+	userAuth, err := s.findUserAuth(ctx, s.db, entity.AuthTypeEmail, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return cerrcode.ErrVerificationCode.Wrap("account not found")
+		}
+		return err
+	}
+
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		if err := s.useVerificationCode(ctx, dbTX, verificationCode); err != nil {
+			return err
+		}
+		if _, err := model.UserAuths.Update(
+			model.UserAuthSetter{
+				AuthToken: lo.ToPtr(passwordHash),
+			}.UpdateMod(),
+			model.UpdateWhere.UserAuths.ID.EQ(userAuth.ID),
+		).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to update password")
+		}
+		return s.tokenSrv.RevokeAll(ctx, dbTX, userAuth.UserID)
+	})
+}
+
+// createVerificationCode replaces the unused codes of the target, it is throttled by mail.code_resend_interval
+func (s *AuthService) createVerificationCode(ctx context.Context, purpose string, target string, code string) error {
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		now := time.Now().UTC()
+		latest, err := model.VerificationCodes.Query(
+			model.SelectWhere.VerificationCodes.Purpose.EQ(purpose),
+			model.SelectWhere.VerificationCodes.Target.EQ(target),
+			sm.OrderBy(model.VerificationCodeColumns.CreateTime).Desc(),
+		).One(ctx, dbTX)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return errors.Wrap(err, "failed to query verification code")
+		}
+		if err == nil && now.Sub(latest.CreateTime) < s.sidecar.Repo.Cfg.Mail.CodeResendInterval.ToDuration() {
+			return cerrcode.ErrTooFrequent.Wrap("please retry later")
+		}
+
+		if _, err := model.VerificationCodes.Update(
+			model.VerificationCodeSetter{
+				DeleteTime: lo.ToPtr(now),
+				DelState:   lo.ToPtr(entity.DelStateDeleted),
+			}.UpdateMod(),
+			model.UpdateWhere.VerificationCodes.Purpose.EQ(purpose),
+			model.UpdateWhere.VerificationCodes.Target.EQ(target),
+			model.UpdateWhere.VerificationCodes.DelState.EQ(entity.DelStateActive),
+		).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to invalidate verification codes")
+		}
+
+		if _, err := model.VerificationCodes.Insert(&model.VerificationCodeSetter{
+			Purpose:    lo.ToPtr(purpose),
+			Target:     lo.ToPtr(target),
+			CodeHash:   lo.ToPtr(hashSecret(code)),
+			ExpireTime: lo.ToPtr(now.Add(s.sidecar.Repo.Cfg.Mail.CodeValidDuration.ToDuration())),
+			Attempts:   lo.ToPtr(int64(0)),
+			UseTime:    lo.ToPtr(time.Time{}),
+		}).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to insert verification code")
+		}
+		return nil
+	})
+}
+
+// checkVerificationCode returns the matched code without consuming it.
+// Every guess takes an attempt atomically before the comparison, so concurrent guesses can not exceed the max attempts,
+// the code is invalidated by the last mismatch
+func (s *AuthService) checkVerificationCode(ctx context.Context, purpose string, target string, code string) (*model.VerificationCode, error) {
+	verificationCode, err := model.VerificationCodes.Query(
+		model.SelectWhere.VerificationCodes.Purpose.EQ(purpose),
+		model.SelectWhere.VerificationCodes.Target.EQ(target),
+		model.SelectWhere.VerificationCodes.DelState.EQ(entity.DelStateActive),
+		sm.OrderBy(model.VerificationCodeColumns.CreateTime).Desc(),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, cerrcode.ErrVerificationCode.Wrap("code not found")
+		}
+		return nil, errors.Wrap(err, "failed to query verification code")
+	}
+	now := time.Now().UTC()
+	if !verificationCode.UseTime.IsZero() || now.After(verificationCode.ExpireTime) {
+		return nil, cerrcode.ErrVerificationCode.Wrap("code expired")
+	}
+
+	// the returned row carries the new version, which useVerificationCode checks
+	verificationCode, err = model.VerificationCodes.Update(
+		um.SetCol(model.ColumnNames.VerificationCodes.Attempts).To(psql.Quote(model.ColumnNames.VerificationCodes.Attempts).OP("+", psql.Arg(1))),
+		model.UpdateWhere.VerificationCodes.ID.EQ(verificationCode.ID),
+		model.UpdateWhere.VerificationCodes.DelState.EQ(entity.DelStateActive),
+		model.UpdateWhere.VerificationCodes.Attempts.LT(verificationCodeMaxAttempts),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, cerrcode.ErrVerificationCode.Wrap("too many attempts")
+		}
+		return nil, errors.Wrap(err, "failed to count verification code attempt")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(verificationCode.CodeHash), []byte(hashSecret(code))) != 1 {
+		if verificationCode.Attempts >= verificationCodeMaxAttempts {
+			if _, err := model.VerificationCodes.Update(
+				model.VerificationCodeSetter{
+					DeleteTime: lo.ToPtr(now),
+					DelState:   lo.ToPtr(entity.DelStateDeleted),
+				}.UpdateMod(),
+				model.UpdateWhere.VerificationCodes.ID.EQ(verificationCode.ID),
+				model.UpdateWhere.VerificationCodes.DelState.EQ(entity.DelStateActive),
+			).Exec(ctx, s.db); err != nil {
+				return nil, errors.Wrap(err, "failed to invalidate verification code")
+			}
+		}
+		return nil, cerrcode.ErrVerificationCode.Wrap("code mismatch")
+	}
+	return verificationCode, nil
+}
+
+// useVerificationCode consumes the code, the version check makes sure it is used only once
+func (s *AuthService) useVerificationCode(ctx context.Context, exec bob.Executor, verificationCode *model.VerificationCode) error {
+	now := time.Now().UTC()
+	used, err := model.VerificationCodes.Update(
+		model.VerificationCodeSetter{
+			UseTime: lo.ToPtr(now),
+		}.UpdateMod(),
+		model.UpdateWhere.VerificationCodes.ID.EQ(verificationCode.ID),
+		model.UpdateWhere.VerificationCodes.Version.EQ(verificationCode.Version),
+	).Exec(ctx, exec)
+	if err != nil {
+		return errors.Wrap(err, "failed to use verification code")
+	}
+	if used == 0 {
+		return cerrcode.ErrVerificationCode.Wrap("code already used")
+	}
+	return nil
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || len(email) > emailMaxLen {
+		return "", cerrcode.ErrRequestParameter.Wrap("invalid email: " + email)
+	}
+	return email, nil
+}
+
+func randomDigits(n int) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate verification code")
+		}
+		sb.WriteString(digit.String())
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+var verificationCodeRegexp = regexp.MustCompile(`code is (\d+)`)
+
+func lastMailCode(t *testing.T, memoryMailer *mailer.MemoryMailer, to string) string {
+	mail, ok := memoryMailer.Last(to)
+	require.True(t, ok)
+	matches := verificationCodeRegexp.FindStringSubmatch(mail.Body)
+	require.Len(t, matches, 2)
+	return matches[1]
+}
+
+func TestAuthService_RegisterAndLoginByEmail(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	memoryMailer := mailer.NewMemoryMailer()
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, memoryMailer, auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	email := "alice@example.com"
+	err = authSrv.SendEmailCode(ctx.Ctx, "Alice@Example.com ", entity.VerificationPurposeRegister)
+	require.Nil(t, err)
+	code := lastMailCode(t, memoryMailer, email)
+
+	// resend is throttled
+	err = authSrv.SendEmailCode(ctx.Ctx, email, entity.VerificationPurposeRegister)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrTooFrequent), errcode.DecodeError(err))
+
+	_, err = authSrv.RegisterByEmail(ctx.Ctx, email, "not-the-code", "password123", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrVerificationCode), errcode.DecodeError(err))
+
+	registerRes, err := authSrv.RegisterByEmail(ctx.Ctx, email, code, "password123", "")
+	require.Nil(t, err)
+	require.NotEmpty(t, registerRes.Token)
+
+	// the code is one-time
+	_, err = authSrv.RegisterByEmail(ctx.Ctx, "bob@example.com", code, "password123", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrVerificationCode), errcode.DecodeError(err))
+
+	loginRes, err := authSrv.LoginByEmail(ctx.Ctx, email, "password123", "")
+	require.Nil(t, err)
+	require.Equal(t, registerRes.UserID, loginRes.UserID)
+
+	// the result does not reveal the account, the mailbox gets a notice instead of a code
+	sidecar.Repo.Cfg.Mail.CodeResendInterval = 0
+	err = authSrv.SendEmailCode(ctx.Ctx, email, entity.VerificationPurposeRegister)
+	require.Nil(t, err)
+	mail, ok := memoryMailer.Last(email)
+	require.True(t, ok)
+	require.Contains(t, mail.Body, "already registered")
+	require.False(t, verificationCodeRegexp.MatchString(mail.Body))
+	err = authSrv.SendEmailCode(ctx.Ctx, "nobody@example.com", entity.VerificationPurposeLogin)
+	require.Nil(t, err)
+	mail, ok = memoryMailer.Last("nobody@example.com")
+	require.True(t, ok)
+	require.Contains(t, mail.Body, "No account")
+
+	err = authSrv.SendEmailCode(ctx.Ctx, email, entity.VerificationPurposeLogin)
+	require.Nil(t, err)
+	codeLoginRes, err := authSrv.LoginByEmailCode(ctx.Ctx, email, lastMailCode(t, memoryMailer, email), "")
+	require.Nil(t, err)
+	require.Equal(t, registerRes.UserID, codeLoginRes.UserID)
+
+	_, err = authSrv.RegisterByEmail(ctx.Ctx, "invalid", code, "password123", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+}
+
+func TestAuthService_VerificationCodeAttempts(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	memoryMailer := mailer.NewMemoryMailer()
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, memoryMailer, auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	email := "alice@example.com"
+	require.Nil(t, authSrv.SendEmailCode(ctx.Ctx, email, entity.VerificationPurposeRegister))
+	code := lastMailCode(t, memoryMailer, email)
+
+	for i := 0; i < verificationCodeMaxAttempts; i++ {
+		_, err = authSrv.RegisterByEmail(ctx.Ctx, email, "wrong", "password123", "")
+		require.Equal(t, errcode.DecodeError(cerrcode.ErrVerificationCode), errcode.DecodeError(err))
+	}
+	// the code is invalidated after too many attempts
+	_, err = authSrv.RegisterByEmail(ctx.Ctx, email, code, "password123", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrVerificationCode), errcode.DecodeError(err))
+}
+
+func TestAuthService_VerificationCodeConcurrentAttempts(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	memoryMailer := mailer.NewMemoryMailer()
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, memoryMailer, auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	email := "alice@example.com"
+	require.Nil(t, authSrv.SendEmailCode(ctx.Ctx, email, entity.VerificationPurposeRegister))
+	code := lastMailCode(t, memoryMailer, email)
+
+	var wg sync.WaitGroup
+	for i := 0; i < verificationCodeMaxAttempts*4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := authSrv.RegisterByEmail(ctx.Ctx, email, "wrong", "password123", "")
+			require.Equal(t, errcode.DecodeError(cerrcode.ErrVerificationCode), errcode.DecodeError(err))
+		}()
+	}
+	wg.Wait()
+
+	verificationCode, err := model.VerificationCodes.Query(
+		model.SelectWhere.VerificationCodes.Target.EQ(email),
+	).One(ctx.Ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, int64(verificationCodeMaxAttempts), verificationCode.Attempts)
+	require.Equal(t, entity.DelStateDeleted, verificationCode.DelState)
+
+	_, err = authSrv.RegisterByEmail(ctx.Ctx, email, code, "password123", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrVerificationCode), errcode.DecodeError(err))
+}
+
+func TestAuthService_ResetPasswordByEmail(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	sidecar.Repo.Cfg.Mail.CodeResendInterval = 0
+	memoryMailer := mailer.NewMemoryMailer()
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, memoryMailer, auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	email := "alice@example.com"
+	require.Nil(t, authSrv.SendEmailCode(ctx.Ctx, email, entity.VerificationPurposeRegister))
+	registerRes, err := authSrv.RegisterByEmail(ctx.Ctx, email, lastMailCode(t, memoryMailer, email), "password123", "")
+	require.Nil(t, err)
+
+	// unknown emails are silently ignored
+	require.Nil(t, authSrv.SendPasswordResetEmail(ctx.Ctx, "nobody@example.com"))
+	_, ok := memoryMailer.Last("nobody@example.com")
+	require.False(t, ok)
+
+	require.Nil(t, authSrv.SendPasswordResetEmail(ctx.Ctx, email))
+	mail, ok := memoryMailer.Last(email)
+	require.True(t, ok)
+	link := strings.TrimSpace(mail.Body[strings.Index(mail.Body, sidecar.Repo.Cfg.Mail.ResetPasswordURL):])
+	linkURL, err := url.Parse(link)
+	require.Nil(t, err)
+	token := linkURL.Query().Get("token")
+	require.NotEmpty(t, token)
+
+	require.Nil(t, authSrv.ResetPasswordByEmail(ctx.Ctx, email, token, "new-password"))
+	err = authSrv.ResetPasswordByEmail(ctx.Ctx, email, token, "new-password2")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrVerificationCode), errcode.DecodeError(err))
+
+	_, err = authSrv.LoginByEmail(ctx.Ctx, email, "password123", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountOrPassword), errcode.DecodeError(err))
+	_, err = authSrv.LoginByEmail(ctx.Ctx, email, "new-password", "")
+	require.Nil(t, err)
+
+	// sessions before the reset are revoked
+	_, err = tokenSrv.Verify(ctx.Ctx, registerRes.Token)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-project-startup/internal/pkg/telegram"
+)
+
+// ListUserAuths returns the active auth methods of the user
+func (s *AuthService) ListUserAuths(ctx context.Context, userID int64) (model.UserAuthSlice, error) {
+	userAuths, err := model.UserAuths.Query(
+		model.SelectWhere.UserAuths.UserID.EQ(userID),
+		model.SelectWhere.UserAuths.DelState.EQ(entity.DelStateActive),
+		sm.OrderBy(model.UserAuthColumns.CreateTime),
+	).All(ctx, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query user auths")
+	}
+	return userAuths, nil
+}
+
+// LinkUsername adds a username auth to the user
+func (s *AuthService) LinkUsername(ctx context.Context, userID int64, username string, password string) error {
+	if len(username) < usernameMinLen || len(username) > usernameMaxLen {
+		return cerrcode.ErrRequestParameter.Wrap(fmt.Sprintf("username length must be between %d and %d", usernameMinLen, usernameMaxLen))
+	}
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	return s.linkAuth(ctx, userID, entity.AuthTypeUsername, username, passwordHash)
+}
+
+// LinkEmail adds an email auth to the user, the ownership is proved by the link code sent to the email
+func (s *AuthService) LinkEmail(ctx context.Context, userID int64, email string, code string, password string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	verificationCode, err := s.checkVerificationCode(ctx, entity.VerificationPurposeLink, email, code)
+	if err != nil {
+		return err
+	}
+	return s.linkAuth(ctx, userID, entity.AuthTypeEmail, email, passwordHash, func(ctx context.Context, dbTX bob.Transaction) error {
+		return s.useVerificationCode(ctx, dbTX, verificationCode)
+	})
+}
+
+// LinkTelegramWidget adds a tg auth to the user, the ownership is proved by the Login Widget data
+func (s *AuthService) LinkTelegramWidget(ctx context.Context, userID int64, fields map[string]string) error {
+	tgUser, err := s.verifyTelegramWidget(fields)
+	if err != nil {
+		return err
+	}
+	return s.linkTelegram(ctx, userID, tgUser)
+}
+
+// LinkTelegramWebApp adds a tg auth to the user, the ownership is proved by the Mini App initData
+func (s *AuthService) LinkTelegramWebApp(ctx context.Context, userID int64, initData string) error {
+	tgUser, err := s.verifyTelegramWebApp(initData)
+	if err != nil {
+		return err
+	}
+	return s.linkTelegram(ctx, userID, tgUser)
+}
+
+// UnlinkAuth removes an auth method of the user, the last one can not be removed
+func (s *AuthService) UnlinkAuth(ctx context.Context, userID int64, userAuthID int64) error {
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		if err := lockUser(ctx, dbTX, userID); err != nil {
+			return err
+		}
+		userAuths, err := model.UserAuths.Query(
+			model.SelectWhere.UserAuths.UserID.EQ(userID),
+			model.SelectWhere.UserAuths.DelState.EQ(entity.DelStateActive),
+		).All(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to query user auths")
+		}
+		userAuth, ok := lo.Find(userAuths, func(item *model.UserAuth) bool {
+			return item.ID == userAuthID
+		})
+		if !ok {
+			return cerrcode.ErrRequestParameter.Wrap("auth not found")
+		}
+		if userAuth.AuthType == entity.AuthTypeTOTP {
+			return cerrcode.ErrRequestParameter.Wrap("totp is removed by disabling it with a code")
+		}
+		// totp can not log in by itself
+		if lo.CountBy(userAuths, func(item *model.UserAuth) bool {
+			return item.AuthType != entity.AuthTypeTOTP
+		}) <= 1 {
+			return cerrcode.ErrLastAuth
+		}
+
+		now := time.Now().UTC()
+		if _, err := model.UserAuths.Update(
+			model.UserAuthSetter{
+				DeleteTime: lo.ToPtr(now),
+				DelState:   lo.ToPtr(entity.DelStateDeleted),
+			}.UpdateMod(),
+			model.UpdateWhere.UserAuths.ID.EQ(userAuthID),
+		).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to delete user auth")
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionUserAuthUnlink,
+			Target: entity.AuditTargetUser(userID),
+			Before: map[string]any{"auth_type": userAuth.AuthType},
+		})
+	})
+}
+
+func (s *AuthService) linkTelegram(ctx context.Context, userID int64, tgUser *telegram.User) error {
+	// telegram has authenticated the account, no token is stored
+	return s.linkAuth(ctx, userID, entity.AuthTypeTelegram, strconv.FormatInt(tgUser.ID, 10), "")
+}
+
+// linkAuth adds the auth to the user, a user has at most one auth of each type
+func (s *AuthService) linkAuth(ctx context.Context, userID int64, authType string, authID string, authToken string, beforeActions ...dao.DBAction) error {
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, append(beforeActions, func(ctx context.Context, dbTX bob.Transaction) error {
+		if err := lockUser(ctx, dbTX, userID); err != nil {
+			return err
+		}
+		if err := checkUserAuthNotExists(ctx, dbTX, authType, authID); err != nil {
+			return err
+		}
+		linked, err := model.UserAuths.Query(
+			model.SelectWhere.UserAuths.UserID.EQ(userID),
+			model.SelectWhere.UserAuths.AuthType.EQ(authType),
+			model.SelectWhere.UserAuths.DelState.EQ(entity.DelStateActive),
+		).Exists(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to query user auth")
+		}
+		if linked {
+			return cerrcode.ErrAccountExists.Wrap("already linked a " + authType + " auth")
+		}
+		if err := s.insertUserAuth(ctx, dbTX, userID, authType, authID, authToken); err != nil {
+			return err
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionUserAuthLink,
+			Target: entity.AuditTargetUser(userID),
+			After:  map[string]any{"auth_type": authType},
+		})
+	})...)
+}
+
+// lockUser takes the row lock of the user by a no-op update (sqlite has no SELECT FOR UPDATE),
+// so concurrent transactions changing the auths of the same user are serialized
+func lockUser(ctx context.Context, exec bob.Executor, userID int64) error {
+	// skip the query hooks so the no-op update does not change the version
+	locked, err := model.Users.Update(
+		um.SetCol(model.ColumnNames.Users.ID).To(psql.Quote(model.ColumnNames.Users.ID)),
+		model.UpdateWhere.Users.ID.EQ(userID),
+		model.UpdateWhere.Users.DelState.EQ(entity.DelStateActive),
+	).Exec(bob.SkipQueryHooks(ctx), exec)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock user")
+	}
+	if locked == 0 {
+		return cerrcode.ErrRequestParameter.Wrap("user not found")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestAuthService_LinkAndUnlink(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	sidecar.Repo.Cfg.Telegram.BotToken = "123456:TEST-bot-token"
+	memoryMailer := mailer.NewMemoryMailer()
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, memoryMailer, auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	tgFields := func(id string) map[string]string {
+		return signTelegramWidget("123456:TEST-bot-token", map[string]string{
+			"id":         id,
+			"first_name": "Alice",
+			"auth_date":  strconv.FormatInt(time.Now().Unix(), 10),
+		})
+	}
+	alice, err := authSrv.LoginByTelegramWidget(ctx.Ctx, tgFields("42"))
+	require.Nil(t, err)
+	bob, err := authSrv.RegisterByUsername(ctx.Ctx, "bob", "password123", "")
+	require.Nil(t, err)
+
+	email := "alice@example.com"
+	require.Nil(t, authSrv.SendEmailCode(ctx.Ctx, email, entity.VerificationPurposeLink))
+	require.Nil(t, authSrv.LinkEmail(ctx.Ctx, alice.UserID, email, lastMailCode(t, memoryMailer, email), "password123"))
+
+	emailLogin, err := authSrv.LoginByEmail(ctx.Ctx, email, "password123", "")
+	require.Nil(t, err)
+	require.Equal(t, alice.UserID, emailLogin.UserID)
+
+	// (auth_type, auth_id) is unique across users
+	err = authSrv.LinkUsername(ctx.Ctx, alice.UserID, "bob", "password123")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountExists), errcode.DecodeError(err))
+	err = authSrv.LinkTelegramWidget(ctx.Ctx, bob.UserID, tgFields("42"))
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountExists), errcode.DecodeError(err))
+	// a user has at most one auth of each type
+	err = authSrv.LinkTelegramWidget(ctx.Ctx, alice.UserID, tgFields("43"))
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountExists), errcode.DecodeError(err))
+
+	userAuths, err := authSrv.ListUserAuths(ctx.Ctx, alice.UserID)
+	require.Nil(t, err)
+	require.Len(t, userAuths, 2)
+	require.Equal(t, entity.AuthTypeTelegram, userAuths[0].AuthType)
+	require.Equal(t, entity.AuthTypeEmail, userAuths[1].AuthType)
+
+	// auths of other users can not be removed
+	bobAuths, err := authSrv.ListUserAuths(ctx.Ctx, bob.UserID)
+	require.Nil(t, err)
+	err = authSrv.UnlinkAuth(ctx.Ctx, alice.UserID, bobAuths[0].ID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	require.Nil(t, authSrv.UnlinkAuth(ctx.Ctx, alice.UserID, userAuths[0].ID))
+	err = authSrv.UnlinkAuth(ctx.Ctx, alice.UserID, userAuths[1].ID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrLastAuth), errcode.DecodeError(err))
+
+	// the unlinked telegram account can be linked again
+	require.Nil(t, authSrv.LinkTelegramWidget(ctx.Ctx, bob.UserID, tgFields("42")))
+	tgLogin, err := authSrv.LoginByTelegramWidget(ctx.Ctx, tgFields("42"))
+	require.Nil(t, err)
+	require.Equal(t, bob.UserID, tgLogin.UserID)
+
+	auditLogs, _, err := auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Target: entity.AuditTargetUser(alice.UserID)}, 0, 0)
+	require.Nil(t, err)
+	auditLogs = lo.Filter(auditLogs, func(item *model.AuditLog, _ int) bool {
+		return item.Action != entity.AuditActionLogin
+	})
+	require.Len(t, auditLogs, 2)
+	require.Equal(t, entity.AuditActionUserAuthUnlink, auditLogs[0].Action)
+	require.JSONEq(t, `{"before":{"auth_type":"tg"},"after":{}}`, auditLogs[0].Diff)
+	require.Equal(t, entity.AuditActionUserAuthLink, auditLogs[1].Action)
+	require.JSONEq(t, `{"before":{},"after":{"auth_type":"email"}}`, auditLogs[1].Diff)
+}
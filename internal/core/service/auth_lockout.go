@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+// loginSubject is a dimension the failed logins are counted on
+type loginSubject struct {
+	scope       string
+	subject     string
+	maxFailures int64
+}
+
+// UnlockUser clears the failed logins of all the auths of the user
+func (s *AuthService) UnlockUser(ctx context.Context, userID int64) error {
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		// joins the transaction by the context
+		if err := s.clearUserLoginFailures(ctx, userID); err != nil {
+			return err
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionLoginUnlock,
+			Target: entity.AuditTargetUser(userID),
+		})
+	})
+}
+
+// UnlockIP clears the failed logins of the client ip
+func (s *AuthService) UnlockIP(ctx context.Context, clientIP string) error {
+	if clientIP == "" {
+		return cerrcode.ErrRequestParameter.Wrap("ip is empty")
+	}
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		if err := s.resetLoginFailures(ctx, entity.LoginScopeIP, clientIP); err != nil {
+			return err
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionLoginUnlock,
+			Target: entity.AuditTargetIP(clientIP),
+		})
+	})
+}
+
+func (s *AuthService) clearUserLoginFailures(ctx context.Context, userID int64) error {
+	userAuths, err := s.ListUserAuths(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(userAuths) == 0 {
+		return cerrcode.ErrRequestParameter.Wrap("user not found")
+	}
+	for _, userAuth := range userAuths {
+		if err := s.resetLoginFailures(ctx, entity.LoginScopeAccount, userAuth.AuthType+":"+userAuth.AuthID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// guardLogin runs the login unless the account or the client ip is locked,
+// failures of the login are counted and a success clears the failures of the account
+func (s *AuthService) guardLogin(ctx context.Context, authType string, authID string, clientIP string, login func() (*AuthToken, error)) (*AuthToken, error) {
+	cfg := s.sidecar.Repo.Cfg.Auth
+	account := loginSubject{scope: entity.LoginScopeAccount, subject: authType + ":" + authID, maxFailures: cfg.LoginMaxFailures}
+	subjects := []loginSubject{account}
+	// ipc calls have no client ip
+	if clientIP != "" {
+		subjects = append(subjects, loginSubject{scope: entity.LoginScopeIP, subject: clientIP, maxFailures: cfg.LoginIPMaxFailures})
+	}
+
+	if err := s.checkLoginLocked(ctx, subjects); err != nil {
+		return nil, err
+	}
+	res, err := login()
+	if err != nil {
+		if code := errcode.DecodeError(err); code == errcode.DecodeError(cerrcode.ErrAccountOrPassword) ||
+			code == errcode.DecodeError(cerrcode.ErrVerificationCode) ||
+			code == errcode.DecodeError(cerrcode.ErrMFACode) {
+			for _, subject := range subjects {
+				if err := s.recordLoginFailure(ctx, subject); err != nil {
+					return nil, err
+				}
+			}
+			if err := s.auditSrv.Record(ctx, AuditEntry{
+				Action: entity.AuditActionLoginFailed,
+				Target: account.subject,
+			}); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+	if err := s.resetLoginFailures(ctx, account.scope, account.subject); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// checkLoginLocked returns ErrLoginLocked with the longest remaining lock of the subjects
+func (s *AuthService) checkLoginLocked(ctx context.Context, subjects []loginSubject) error {
+	now := time.Now().UTC()
+	var lockUntil time.Time
+	for _, subject := range subjects {
+		loginAttempt, err := model.LoginAttempts.Query(
+			model.SelectWhere.LoginAttempts.Scope.EQ(subject.scope),
+			model.SelectWhere.LoginAttempts.Subject.EQ(subject.subject),
+		).One(ctx, s.db)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return errors.Wrap(err, "failed to query login attempt")
+		}
+		if loginAttempt.LockUntil.After(lockUntil) {
+			lockUntil = loginAttempt.LockUntil
+		}
+	}
+	if !lockUntil.After(now) {
+		return nil
+	}
+	retryAfter := lockUntil.Sub(now).Round(time.Second)
+	if retryAfter < time.Second {
+		retryAfter = time.Second
+	}
+	return cerrcode.WithRetryAfter(cerrcode.ErrLoginLocked.Wrap("retry after "+retryAfter.String()), retryAfter)
+}
+
+// recordLoginFailure counts the failure and locks the subject once it reaches the max failures,
+// the row is locked first so concurrent failures are all counted
+func (s *AuthService) recordLoginFailure(ctx context.Context, subject loginSubject) error {
+	cfg := s.sidecar.Repo.Cfg.Auth
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		now := time.Now().UTC()
+		locked, err := lockLoginAttempt(ctx, dbTX, subject)
+		if err != nil {
+			return err
+		}
+		if locked == 0 {
+			inserted, err := insertLoginAttempt(ctx, dbTX, subject, now, loginLockUntil(now, 1, subject.maxFailures, cfg.LoginLockDuration.ToDuration(), cfg.LoginMaxLockDuration.ToDuration()))
+			if err != nil {
+				return err
+			}
+			if inserted {
+				return nil
+			}
+			// a concurrent failure has inserted the row first, count on it
+			if locked, err = lockLoginAttempt(ctx, dbTX, subject); err != nil {
+				return err
+			}
+			if locked == 0 {
+				return errors.Errorf("login attempt of %s %s not found after the insert conflict", subject.scope, subject.subject)
+			}
+		}
+
+		loginAttempt, err := model.LoginAttempts.Query(
+			model.SelectWhere.LoginAttempts.Scope.EQ(subject.scope),
+			model.SelectWhere.LoginAttempts.Subject.EQ(subject.subject),
+		).One(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to query login attempt")
+		}
+		failCount := loginAttempt.FailCount + 1
+		if now.Sub(loginAttempt.LastFailTime) > cfg.LoginFailureWindow.ToDuration() {
+			failCount = 1
+		}
+		if _, err := model.LoginAttempts.Update(
+			model.LoginAttemptSetter{
+				FailCount:    lo.ToPtr(failCount),
+				LastFailTime: lo.ToPtr(now),
+				LockUntil:    lo.ToPtr(loginLockUntil(now, failCount, subject.maxFailures, cfg.LoginLockDuration.ToDuration(), cfg.LoginMaxLockDuration.ToDuration())),
+			}.UpdateMod(),
+			model.UpdateWhere.LoginAttempts.ID.EQ(loginAttempt.ID),
+		).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to update login attempt")
+		}
+		return nil
+	})
+}
+
+// lockLoginAttempt takes the row lock of the login attempt by a no-op update and returns the number of the locked rows
+func lockLoginAttempt(ctx context.Context, exec bob.Executor, subject loginSubject) (int64, error) {
+	locked, err := model.LoginAttempts.Update(
+		um.SetCol(model.ColumnNames.LoginAttempts.ID).To(psql.Quote(model.ColumnNames.LoginAttempts.ID)),
+		model.UpdateWhere.LoginAttempts.Scope.EQ(subject.scope),
+		model.UpdateWhere.LoginAttempts.Subject.EQ(subject.subject),
+	).Exec(bob.SkipQueryHooks(ctx), exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to lock login attempt")
+	}
+	return locked, nil
+}
+
+// insertLoginAttempt inserts the first failure of the subject, false is returned if the row exists,
+// so the concurrent first failures do not fail on the unique (scope, subject) index
+func insertLoginAttempt(ctx context.Context, exec bob.Executor, subject loginSubject, failTime time.Time, lockUntil time.Time) (bool, error) {
+	_, err := model.LoginAttempts.Insert(
+		&model.LoginAttemptSetter{
+			Scope:        lo.ToPtr(subject.scope),
+			Subject:      lo.ToPtr(subject.subject),
+			FailCount:    lo.ToPtr(int64(1)),
+			LastFailTime: lo.ToPtr(failTime),
+			LockUntil:    lo.ToPtr(lockUntil),
+		},
+		im.OnConflict(
+			psql.Quote(model.ColumnNames.LoginAttempts.Scope),
+			psql.Quote(model.ColumnNames.LoginAttempts.Subject),
+		).DoNothing(),
+	).One(ctx, exec)
+	if err != nil {
+		// nothing is returned on conflict
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to insert login attempt")
+	}
+	return true, nil
+}
+
+func (s *AuthService) resetLoginFailures(ctx context.Context, scope string, subject string) error {
+	if _, err := model.LoginAttempts.Update(
+		model.LoginAttemptSetter{
+			FailCount: lo.ToPtr(int64(0)),
+			LockUntil: lo.ToPtr(time.Time{}),
+		}.UpdateMod(),
+		model.UpdateWhere.LoginAttempts.Scope.EQ(scope),
+		model.UpdateWhere.LoginAttempts.Subject.EQ(subject),
+		model.UpdateWhere.LoginAttempts.FailCount.GT(0),
+	).Exec(ctx, s.db); err != nil {
+		return errors.Wrap(err, "failed to reset login attempt")
+	}
+	return nil
+}
+
+// loginLockUntil returns zero time below the max failures, otherwise the lock doubles on every further failure
+func loginLockUntil(now time.Time, failCount int64, maxFailures int64, lockDuration time.Duration, maxLockDuration time.Duration) time.Time {
+	if maxFailures <= 0 || failCount < maxFailures {
+		return time.Time{}
+	}
+	lock := lockDuration
+	for i := maxFailures; i < failCount && lock < maxLockDuration; i++ {
+		lock *= 2
+	}
+	return now.Add(min(lock, maxLockDuration))
+}
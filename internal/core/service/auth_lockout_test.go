@@ -0,0 +1,118 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestAuthService_LoginLockout(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	sidecar.Repo.Cfg.Auth.LoginMaxFailures = 3
+	sidecar.Repo.Cfg.Auth.LoginIPMaxFailures = 5
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	alice, err := authSrv.RegisterByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	_, err = authSrv.RegisterByUsername(ctx.Ctx, "bob", "password123", "")
+	require.Nil(t, err)
+
+	// a success clears the failures of the account
+	for i := 0; i < 2; i++ {
+		_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "wrong-password", "10.0.0.1")
+		require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountOrPassword), errcode.DecodeError(err))
+	}
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "10.0.0.1")
+	require.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "wrong-password", "10.0.0.2")
+		require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountOrPassword), errcode.DecodeError(err))
+	}
+	// the right password is rejected while locked, from any ip
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "10.0.0.3")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrLoginLocked), errcode.DecodeError(err))
+	var retryAfterErr *cerrcode.RetryAfterError
+	require.True(t, errors.As(err, &retryAfterErr))
+	require.Equal(t, time.Minute, retryAfterErr.RetryAfter)
+
+	require.Nil(t, authSrv.UnlockUser(ctx.Ctx, alice.UserID))
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "10.0.0.3")
+	require.Nil(t, err)
+
+	// 10.0.0.2 has failed 3 times, 2 more failures on other accounts lock the ip
+	for _, username := range []string{"bob", "nobody"} {
+		_, err = authSrv.LoginByUsername(ctx.Ctx, username, "wrong-password", "10.0.0.2")
+		require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountOrPassword), errcode.DecodeError(err))
+	}
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "bob", "password123", "10.0.0.2")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrLoginLocked), errcode.DecodeError(err))
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "bob", "password123", "")
+	require.Nil(t, err)
+
+	require.Nil(t, authSrv.UnlockIP(ctx.Ctx, "10.0.0.2"))
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "bob", "password123", "10.0.0.2")
+	require.Nil(t, err)
+
+	auditLogs, _, err := auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Action: entity.AuditActionLoginUnlock}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 2)
+	require.Equal(t, entity.AuditTargetIP("10.0.0.2"), auditLogs[0].Target)
+	require.Equal(t, entity.AuditTargetUser(alice.UserID), auditLogs[1].Target)
+}
+
+func TestLoginLockUntil(t *testing.T) {
+	now := time.Now()
+	require.True(t, loginLockUntil(now, 4, 5, time.Minute, time.Hour).IsZero())
+	require.Equal(t, now.Add(time.Minute), loginLockUntil(now, 5, 5, time.Minute, time.Hour))
+	require.Equal(t, now.Add(4*time.Minute), loginLockUntil(now, 7, 5, time.Minute, time.Hour))
+	require.Equal(t, now.Add(time.Hour), loginLockUntil(now, 100, 5, time.Minute, time.Hour))
+	// disabled
+	require.True(t, loginLockUntil(now, 100, 0, time.Minute, time.Hour).IsZero())
+}
+
+func TestAuthService_RecordLoginFailureConflict(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	subject := loginSubject{scope: entity.LoginScopeIP, subject: "10.0.0.1", maxFailures: 3}
+	now := time.Now().UTC()
+
+	// the row inserted by a concurrent first failure is reported instead of failing on the unique index
+	inserted, err := insertLoginAttempt(ctx.Ctx, sqlConnector.Executor, subject, now, time.Time{})
+	require.Nil(t, err)
+	require.True(t, inserted)
+	inserted, err = insertLoginAttempt(ctx.Ctx, sqlConnector.Executor, subject, now, time.Time{})
+	require.Nil(t, err)
+	require.False(t, inserted)
+
+	require.Nil(t, authSrv.recordLoginFailure(ctx.Ctx, subject))
+	loginAttempt, err := model.LoginAttempts.Query(
+		model.SelectWhere.LoginAttempts.Scope.EQ(subject.scope),
+		model.SelectWhere.LoginAttempts.Subject.EQ(subject.subject),
+	).One(ctx.Ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), loginAttempt.FailCount)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-project-startup/internal/pkg/oidc"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+const oidcRequestTimeout = 10 * time.Second
+
+// the provider name is part of auth_type varchar(20) after "oidc:"
+var authProviderNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,15}$`)
+
+// RegisterAuthProvider adds an oauth2 provider, the users of it are stored as auth type oidc:<name>
+func (s *AuthService) RegisterAuthProvider(provider oidc.AuthProvider) error {
+	if !authProviderNameRegexp.MatchString(provider.Name()) {
+		return errors.Errorf("invalid auth provider name: %s", provider.Name())
+	}
+	if _, ok := s.authProviders[provider.Name()]; ok {
+		return errors.Errorf("duplicate auth provider: %s", provider.Name())
+	}
+	s.authProviders[provider.Name()] = provider
+	return nil
+}
+
+// ListAuthProviders returns the names of the providers
+func (s *AuthService) ListAuthProviders() []string {
+	names := lo.Keys(s.authProviders)
+	sort.Strings(names)
+	return names
+}
+
+// StartOIDC returns the provider login page, the provider redirects back with the code and the state,
+// userID is non-zero when linking the provider to the user
+func (s *AuthService) StartOIDC(ctx context.Context, providerName string, userID int64) (string, error) {
+	provider, err := s.authProvider(providerName)
+	if err != nil {
+		return "", err
+	}
+	state, err := oidc.RandomString(32)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := oidc.RandomString(32)
+	if err != nil {
+		return "", err
+	}
+	codeVerifier, codeChallenge, err := oidc.NewPKCE()
+	if err != nil {
+		return "", err
+	}
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, codeChallenge)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build auth url")
+	}
+	sealedCodeVerifier, err := s.secretBox.Seal([]byte(codeVerifier))
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	if _, err := model.OauthStates.Insert(&model.OauthStateSetter{
+		Provider:     lo.ToPtr(providerName),
+		StateHash:    lo.ToPtr(hashSecret(state)),
+		Nonce:        lo.ToPtr(nonce),
+		CodeVerifier: lo.ToPtr(sealedCodeVerifier),
+		UserID:       lo.ToPtr(userID),
+		ExpireTime:   lo.ToPtr(now.Add(s.sidecar.Repo.Cfg.OIDC.StateValidDuration.ToDuration())),
+		UseTime:      lo.ToPtr(time.Time{}),
+	}).Exec(ctx, s.db); err != nil {
+		return "", errors.Wrap(err, "failed to insert oauth state")
+	}
+	return authURL, nil
+}
+
+// LoginByOIDC finishes the login started by StartOIDC, the user is created on the first login
+func (s *AuthService) LoginByOIDC(ctx context.Context, providerName string, state string, code string) (*AuthToken, error) {
+	identity, err := s.finishOIDC(ctx, providerName, state, code, 0)
+	if err != nil {
+		return nil, err
+	}
+	authType := entity.AuthTypeOIDCPrefix + providerName
+	userAuth, err := s.findUserAuth(ctx, s.db, authType, identity.Subject)
+	if err == nil {
+		return s.login(ctx, userAuth)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	nickname := identity.Name
+	if nickname == "" {
+		nickname = providerName + "_user"
+	}
+	// the provider has authenticated the account, no token is stored
+	authToken, err := s.register(ctx, nickname, authType, identity.Subject, "")
+	if err != nil {
+		// another request created the user concurrently
+		if errcode.DecodeError(err) == errcode.DecodeError(cerrcode.ErrAccountExists) {
+			userAuth, err := s.findUserAuth(ctx, s.db, authType, identity.Subject)
+			if err != nil {
+				return nil, err
+			}
+			return s.login(ctx, userAuth)
+		}
+		return nil, err
+	}
+	return authToken, nil
+}
+
+// LinkOIDC finishes the linking started by StartOIDC with the user id
+func (s *AuthService) LinkOIDC(ctx context.Context, userID int64, providerName string, state string, code string) error {
+	identity, err := s.finishOIDC(ctx, providerName, state, code, userID)
+	if err != nil {
+		return err
+	}
+	return s.linkAuth(ctx, userID, entity.AuthTypeOIDCPrefix+providerName, identity.Subject, "")
+}
+
+// finishOIDC consumes the state and redeems the code, the state is bound to the provider and the user
+func (s *AuthService) finishOIDC(ctx context.Context, providerName string, state string, code string, userID int64) (*oidc.Identity, error) {
+	provider, err := s.authProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	oauthState, err := model.OauthStates.Query(
+		model.SelectWhere.OauthStates.StateHash.EQ(hashSecret(state)),
+		model.SelectWhere.OauthStates.DelState.EQ(entity.DelStateActive),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, cerrcode.ErrAuthCode.Wrap("oauth state not found")
+		}
+		return nil, errors.Wrap(err, "failed to query oauth state")
+	}
+	now := time.Now().UTC()
+	if oauthState.Provider != providerName || oauthState.UserID != userID {
+		return nil, cerrcode.ErrAuthCode.Wrap("oauth state mismatch")
+	}
+	if !oauthState.UseTime.IsZero() || now.After(oauthState.ExpireTime) {
+		return nil, cerrcode.ErrAuthCode.Wrap("oauth state expired")
+	}
+	used, err := model.OauthStates.Update(
+		model.OauthStateSetter{
+			UseTime: lo.ToPtr(now),
+		}.UpdateMod(),
+		model.UpdateWhere.OauthStates.ID.EQ(oauthState.ID),
+		model.UpdateWhere.OauthStates.Version.EQ(oauthState.Version),
+	).Exec(ctx, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to use oauth state")
+	}
+	if used == 0 {
+		return nil, cerrcode.ErrAuthCode.Wrap("oauth state already used")
+	}
+
+	codeVerifier, err := s.secretBox.Open(oauthState.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := provider.Exchange(ctx, code, string(codeVerifier), oauthState.Nonce)
+	if err != nil {
+		return nil, cerrcode.ErrAuthCode.Wrap(err.Error())
+	}
+	return identity, nil
+}
+
+func (s *AuthService) authProvider(name string) (oidc.AuthProvider, error) {
+	provider, ok := s.authProviders[name]
+	if !ok {
+		return nil, cerrcode.ErrRequestParameter.Wrap("unknown auth provider: " + name)
+	}
+	return provider, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/pkg/config"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-project-startup/internal/pkg/oidc/oidctest"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestAuthService_OIDC(t *testing.T) {
+	server, err := oidctest.NewServer("client", "secret")
+	require.Nil(t, err)
+	defer server.Close()
+
+	sidecar, sqlConnector := PrepareDB(t)
+	sidecar.Repo.Cfg.OIDC.Providers = []config.OIDCProvider{{
+		Name:         "fake",
+		Issuer:       server.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/oidc/callback",
+	}}
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+	require.Equal(t, []string{"fake"}, authSrv.ListAuthProviders())
+
+	ctx := sidecar.BackgroundContext()
+	alice := oidctest.User{Subject: "alice-sub", Email: "alice@example.com", Name: "Alice"}
+	start := func(userID int64, user oidctest.User) (state string, code string) {
+		authURL, err := authSrv.StartOIDC(ctx.Ctx, "fake", userID)
+		require.Nil(t, err)
+		code, state, err = server.Authorize(authURL, user)
+		require.Nil(t, err)
+		return state, code
+	}
+
+	_, err = authSrv.StartOIDC(ctx.Ctx, "unknown", 0)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	// the user is created on the first login
+	state, code := start(0, alice)
+	first, err := authSrv.LoginByOIDC(ctx.Ctx, "fake", state, code)
+	require.Nil(t, err)
+	require.NotEmpty(t, first.Token)
+	// the state is one-time
+	_, err = authSrv.LoginByOIDC(ctx.Ctx, "fake", state, code)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+
+	state, code = start(0, alice)
+	second, err := authSrv.LoginByOIDC(ctx.Ctx, "fake", state, code)
+	require.Nil(t, err)
+	require.Equal(t, first.UserID, second.UserID)
+
+	_, err = authSrv.LoginByOIDC(ctx.Ctx, "fake", "forged-state", code)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+
+	// a login state can not be used for linking
+	bob, err := authSrv.RegisterByUsername(ctx.Ctx, "bob", "password123", "")
+	require.Nil(t, err)
+	bobAccount := oidctest.User{Subject: "bob-sub", Name: "Bob"}
+	state, code = start(0, bobAccount)
+	err = authSrv.LinkOIDC(ctx.Ctx, bob.UserID, "fake", state, code)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+
+	state, code = start(bob.UserID, bobAccount)
+	require.Nil(t, authSrv.LinkOIDC(ctx.Ctx, bob.UserID, "fake", state, code))
+	userAuths, err := authSrv.ListUserAuths(ctx.Ctx, bob.UserID)
+	require.Nil(t, err)
+	require.Len(t, userAuths, 2)
+	require.Equal(t, entity.AuthTypeOIDCPrefix+"fake", userAuths[1].AuthType)
+	require.Equal(t, "bob-sub", userAuths[1].AuthID)
+
+	state, code = start(0, bobAccount)
+	bobLogin, err := authSrv.LoginByOIDC(ctx.Ctx, "fake", state, code)
+	require.Nil(t, err)
+	require.Equal(t, bob.UserID, bobLogin.UserID)
+
+	// the provider account of alice can not be linked to bob
+	state, code = start(bob.UserID, alice)
+	err = authSrv.LinkOIDC(ctx.Ctx, bob.UserID, "fake", state, code)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountExists), errcode.DecodeError(err))
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-project-startup/internal/pkg/telegram"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+// LoginByTelegramWidget verifies the fields sent by the Telegram Login Widget and logs in,
+// the user is created on the first login
+func (s *AuthService) LoginByTelegramWidget(ctx context.Context, fields map[string]string) (*AuthToken, error) {
+	tgUser, err := s.verifyTelegramWidget(fields)
+	if err != nil {
+		return nil, err
+	}
+	return s.loginByTelegram(ctx, tgUser)
+}
+
+// LoginByTelegramWebApp verifies the initData of a Telegram Mini App and logs in,
+// the user is created on the first login
+func (s *AuthService) LoginByTelegramWebApp(ctx context.Context, initData string) (*AuthToken, error) {
+	tgUser, err := s.verifyTelegramWebApp(initData)
+	if err != nil {
+		return nil, err
+	}
+	return s.loginByTelegram(ctx, tgUser)
+}
+
+func (s *AuthService) loginByTelegram(ctx context.Context, tgUser *telegram.User) (*AuthToken, error) {
+	authID := strconv.FormatInt(tgUser.ID, 10)
+	userAuth, err := s.findUserAuth(ctx, s.db, entity.AuthTypeTelegram, authID)
+	if err == nil {
+		return s.login(ctx, userAuth)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	nickname := tgUser.DisplayName()
+	if nickname == "" {
+		nickname = "tg_" + authID
+	}
+	// telegram has authenticated the account, no token is stored
+	authToken, err := s.register(ctx, nickname, entity.AuthTypeTelegram, authID, "")
+	if err != nil {
+		// another request created the user concurrently
+		if errcode.DecodeError(err) == errcode.DecodeError(cerrcode.ErrAccountExists) {
+			userAuth, err := s.findUserAuth(ctx, s.db, entity.AuthTypeTelegram, authID)
+			if err != nil {
+				return nil, err
+			}
+			return s.login(ctx, userAuth)
+		}
+		return nil, err
+	}
+	return authToken, nil
+}
+
+func (s *AuthService) verifyTelegramWidget(fields map[string]string) (*telegram.User, error) {
+	cfg := s.sidecar.Repo.Cfg.Telegram
+	if cfg.BotToken == "" {
+		return nil, cerrcode.ErrRequestParameter.Wrap("telegram login is disabled")
+	}
+	tgUser, err := telegram.VerifyLoginWidget(cfg.BotToken, fields, cfg.AuthValidDuration.ToDuration())
+	if err != nil {
+		return nil, cerrcode.ErrAuthCode.Wrap(err.Error())
+	}
+	return tgUser, nil
+}
+
+func (s *AuthService) verifyTelegramWebApp(initData string) (*telegram.User, error) {
+	cfg := s.sidecar.Repo.Cfg.Telegram
+	if cfg.BotToken == "" {
+		return nil, cerrcode.ErrRequestParameter.Wrap("telegram login is disabled")
+	}
+	tgUser, err := telegram.VerifyWebAppInitData(cfg.BotToken, initData, cfg.AuthValidDuration.ToDuration())
+	if err != nil {
+		return nil, cerrcode.ErrAuthCode.Wrap(err.Error())
+	}
+	return tgUser, nil
+}
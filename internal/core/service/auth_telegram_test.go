@@ -0,0 +1,66 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-project-startup/internal/pkg/telegram"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func signTelegramWidget(botToken string, fields map[string]string) map[string]string {
+	secretKey := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secretKey[:])
+	mac.Write([]byte(telegram.DataCheckString(fields)))
+	fields["hash"] = hex.EncodeToString(mac.Sum(nil))
+	return fields
+}
+
+func TestAuthService_LoginByTelegramWidget(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	fields := map[string]string{
+		"id":         "42",
+		"first_name": "Alice",
+		"last_name":  "Liddell",
+		"auth_date":  strconv.FormatInt(time.Now().Unix(), 10),
+	}
+	_, err = authSrv.LoginByTelegramWidget(ctx.Ctx, signTelegramWidget("123456:TEST-bot-token", fields))
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	sidecar.Repo.Cfg.Telegram.BotToken = "123456:TEST-bot-token"
+	first, err := authSrv.LoginByTelegramWidget(ctx.Ctx, signTelegramWidget("123456:TEST-bot-token", fields))
+	require.Nil(t, err)
+	second, err := authSrv.LoginByTelegramWidget(ctx.Ctx, signTelegramWidget("123456:TEST-bot-token", fields))
+	require.Nil(t, err)
+	require.Equal(t, first.UserID, second.UserID)
+
+	user, err := model.FindUser(ctx.Ctx, sqlConnector.DB, first.UserID)
+	require.Nil(t, err)
+	require.Equal(t, "Alice Liddell", user.Nickname)
+	userAuth, err := model.UserAuths.Query(model.SelectWhere.UserAuths.UserID.EQ(first.UserID)).One(ctx.Ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, entity.AuthTypeTelegram, userAuth.AuthType)
+	require.Equal(t, "42", userAuth.AuthID)
+
+	_, err = authSrv.LoginByTelegramWidget(ctx.Ctx, signTelegramWidget("654321:other-bot-token", fields))
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+}
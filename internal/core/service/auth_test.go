@@ -0,0 +1,73 @@
+package service
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestAuthService_RegisterAndLoginByUsername(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	registerRes, err := authSrv.RegisterByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	require.NotEmpty(t, registerRes.Token)
+
+	claims, err := tokenSrv.Parse(registerRes.Token)
+	require.Nil(t, err)
+	require.Equal(t, strconv.FormatInt(registerRes.UserID, 10), claims.Subject)
+	require.Equal(t, entity.UserRoleNormal, claims.Role)
+
+	user, err := model.FindUser(ctx.Ctx, sqlConnector.DB, registerRes.UserID)
+	require.Nil(t, err)
+	require.Equal(t, "alice", user.Nickname)
+	require.Equal(t, entity.UserRoleNormal, user.Role)
+
+	userAuth, err := model.UserAuths.Query(model.SelectWhere.UserAuths.UserID.EQ(registerRes.UserID)).One(ctx.Ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Equal(t, entity.AuthTypeUsername, userAuth.AuthType)
+	require.NotEqual(t, "password123", userAuth.AuthToken)
+
+	_, err = authSrv.RegisterByUsername(ctx.Ctx, "alice", "password456", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountExists), errcode.DecodeError(err))
+
+	_, err = authSrv.RegisterByUsername(ctx.Ctx, "bob", "short", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	loginRes, err := authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	require.Equal(t, registerRes.UserID, loginRes.UserID)
+
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "wrong-password", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountOrPassword), errcode.DecodeError(err))
+
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "nobody", "password123", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountOrPassword), errcode.DecodeError(err))
+	// an unknown account costs the same password check as a known one
+	passwordHash, err := hashPassword("password123")
+	require.Nil(t, err)
+	passwordCost, err := bcrypt.Cost([]byte(passwordHash))
+	require.Nil(t, err)
+	dummyCost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	require.Nil(t, err)
+	require.Equal(t, passwordCost, dummyCost)
+
+	_, err = tokenSrv.Parse(loginRes.Token + "x")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-project-startup/internal/pkg/totp"
+	"github.com/zunkk/go-sidecar/repo"
+)
+
+const (
+	// codes of one time step before and after now are accepted for clock drift
+	totpSkew          = 1
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 5
+)
+
+// TOTPEnrollment is shown to the user to set up the authenticator app
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// EnrollTOTP generates a totp secret for the user, totp is enabled once ConfirmTOTP proves the app is set up
+func (s *AuthService) EnrollTOTP(ctx context.Context, userID int64) (*TOTPEnrollment, error) {
+	user, err := model.Users.Query(
+		model.SelectWhere.Users.ID.EQ(userID),
+		model.SelectWhere.Users.DelState.EQ(entity.DelStateActive),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, cerrcode.ErrRequestParameter.Wrap("user not found")
+		}
+		return nil, errors.Wrap(err, "failed to query user")
+	}
+	if _, err := s.findTOTPAuth(ctx, userID); err == nil {
+		return nil, cerrcode.ErrAccountExists.Wrap("totp already enabled")
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	return &TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.URI(repo.AppName, user.Nickname, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables totp with the secret of EnrollTOTP and a code generated by the app,
+// the returned recovery codes are not stored in plaintext and can not be read again
+func (s *AuthService) ConfirmTOTP(ctx context.Context, userID int64, secret string, code string) ([]string, error) {
+	if err := totp.CheckSecret(secret); err != nil {
+		return nil, cerrcode.ErrRequestParameter.Wrap(err.Error())
+	}
+	if _, ok := totp.Validate(secret, code, s.now(), totpSkew); !ok {
+		return nil, cerrcode.ErrMFACode.Wrap("code mismatch")
+	}
+	sealedSecret, err := s.secretBox.Seal([]byte(secret))
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.linkAuth(ctx, userID, entity.AuthTypeTOTP, strconv.FormatInt(userID, 10), sealedSecret, func(ctx context.Context, dbTX bob.Transaction) error {
+		return s.replaceRecoveryCodes(ctx, dbTX, userID, recoveryCodes)
+	}); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// VerifyMFA finishes the login started by a first factor, code is either a totp code or a recovery code
+func (s *AuthService) VerifyMFA(ctx context.Context, mfaToken string, code string, clientIP string) (*AuthToken, error) {
+	claims, err := s.tokenSrv.ParseMFAToken(mfaToken)
+	if err != nil {
+		return nil, cerrcode.ErrAuthCode.Wrap(err.Error())
+	}
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, cerrcode.ErrAuthCode.Wrap("token data invalid: subject is not user id")
+	}
+	if err := s.verifySecondFactor(ctx, userID, code, clientIP); err != nil {
+		return nil, err
+	}
+
+	user, err := model.Users.Query(
+		model.SelectWhere.Users.ID.EQ(userID),
+		model.SelectWhere.Users.DelState.EQ(entity.DelStateActive),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, cerrcode.ErrAuthCode.Wrap("user not found")
+		}
+		return nil, errors.Wrap(err, "failed to query user")
+	}
+	if user.TokenGeneration != claims.TokenGeneration {
+		return nil, cerrcode.ErrAuthCode.Wrap("token has been revoked")
+	}
+	authToken, err := s.tokenSrv.Issue(ctx, s.db, user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordLogin(ctx, user.ID, entity.AuthTypeTOTP, false); err != nil {
+		return nil, err
+	}
+	return authToken, nil
+}
+
+// DisableTOTP removes the totp and the recovery codes of the user
+func (s *AuthService) DisableTOTP(ctx context.Context, userID int64, code string, clientIP string) error {
+	if err := s.verifySecondFactor(ctx, userID, code, clientIP); err != nil {
+		return err
+	}
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		now := time.Now().UTC()
+		if _, err := model.UserAuths.Update(
+			model.UserAuthSetter{
+				DeleteTime: lo.ToPtr(now),
+				DelState:   lo.ToPtr(entity.DelStateDeleted),
+			}.UpdateMod(),
+			model.UpdateWhere.UserAuths.UserID.EQ(userID),
+			model.UpdateWhere.UserAuths.AuthType.EQ(entity.AuthTypeTOTP),
+			model.UpdateWhere.UserAuths.DelState.EQ(entity.DelStateActive),
+		).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to delete totp auth")
+		}
+		if err := s.replaceRecoveryCodes(ctx, dbTX, userID, nil); err != nil {
+			return err
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionUserTOTPDisable,
+			Target: entity.AuditTargetUser(userID),
+		})
+	})
+}
+
+// RegenerateRecoveryCodes invalidates the recovery codes of the user and returns new ones
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID int64, code string, clientIP string) ([]string, error) {
+	if err := s.verifySecondFactor(ctx, userID, code, clientIP); err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		return s.replaceRecoveryCodes(ctx, dbTX, userID, recoveryCodes)
+	}); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// verifySecondFactor checks the code under the same lockout as the logins
+func (s *AuthService) verifySecondFactor(ctx context.Context, userID int64, code string, clientIP string) error {
+	_, err := s.guardLogin(ctx, entity.AuthTypeTOTP, strconv.FormatInt(userID, 10), clientIP, func() (*AuthToken, error) {
+		totpAuth, err := s.findTOTPAuth(ctx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, cerrcode.ErrRequestParameter.Wrap("totp is not enabled")
+			}
+			return nil, err
+		}
+		if len(code) == totp.Digits {
+			return nil, s.useTOTPCode(ctx, totpAuth, code)
+		}
+		return nil, s.useRecoveryCode(ctx, userID, code)
+	})
+	return err
+}
+
+// useTOTPCode accepts each code only once, the time step of the last used code is kept in last_login_time
+func (s *AuthService) useTOTPCode(ctx context.Context, totpAuth *model.UserAuth, code string) error {
+	secret, err := s.secretBox.Open(totpAuth.AuthToken)
+	if err != nil {
+		return err
+	}
+	step, ok := totp.Validate(string(secret), code, s.now(), totpSkew)
+	if !ok {
+		return cerrcode.ErrMFACode.Wrap("code mismatch")
+	}
+	if step <= totp.Step(totpAuth.LastLoginTime) {
+		return cerrcode.ErrMFACode.Wrap("code already used")
+	}
+	used, err := model.UserAuths.Update(
+		model.UserAuthSetter{
+			LastLoginTime: lo.ToPtr(totp.StepTime(step)),
+		}.UpdateMod(),
+		model.UpdateWhere.UserAuths.ID.EQ(totpAuth.ID),
+		model.UpdateWhere.UserAuths.Version.EQ(totpAuth.Version),
+	).Exec(ctx, s.db)
+	if err != nil {
+		return errors.Wrap(err, "failed to use totp code")
+	}
+	if used == 0 {
+		return cerrcode.ErrMFACode.Wrap("code already used")
+	}
+	return nil
+}
+
+func (s *AuthService) useRecoveryCode(ctx context.Context, userID int64, code string) error {
+	recoveryCode, err := model.RecoveryCodes.Query(
+		model.SelectWhere.RecoveryCodes.UserID.EQ(userID),
+		model.SelectWhere.RecoveryCodes.CodeHash.EQ(hashSecret(normalizeRecoveryCode(code))),
+		model.SelectWhere.RecoveryCodes.DelState.EQ(entity.DelStateActive),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return cerrcode.ErrMFACode.Wrap("code mismatch")
+		}
+		return errors.Wrap(err, "failed to query recovery code")
+	}
+	if !recoveryCode.UseTime.IsZero() {
+		return cerrcode.ErrMFACode.Wrap("code already used")
+	}
+	now := time.Now().UTC()
+	used, err := model.RecoveryCodes.Update(
+		model.RecoveryCodeSetter{
+			UseTime: lo.ToPtr(now),
+		}.UpdateMod(),
+		model.UpdateWhere.RecoveryCodes.ID.EQ(recoveryCode.ID),
+		model.UpdateWhere.RecoveryCodes.Version.EQ(recoveryCode.Version),
+	).Exec(ctx, s.db)
+	if err != nil {
+		return errors.Wrap(err, "failed to use recovery code")
+	}
+	if used == 0 {
+		return cerrcode.ErrMFACode.Wrap("code already used")
+	}
+	return nil
+}
+
+// findTOTPAuth returns an error wrapping sql.ErrNoRows if totp is not enabled
+func (s *AuthService) findTOTPAuth(ctx context.Context, userID int64) (*model.UserAuth, error) {
+	return s.findUserAuth(ctx, s.db, entity.AuthTypeTOTP, strconv.FormatInt(userID, 10))
+}
+
+// replaceRecoveryCodes invalidates the old codes of the user and stores the hashes of the new ones
+func (s *AuthService) replaceRecoveryCodes(ctx context.Context, exec bob.Executor, userID int64, recoveryCodes []string) error {
+	now := time.Now().UTC()
+	if _, err := model.RecoveryCodes.Update(
+		model.RecoveryCodeSetter{
+			DeleteTime: lo.ToPtr(now),
+			DelState:   lo.ToPtr(entity.DelStateDeleted),
+		}.UpdateMod(),
+		model.UpdateWhere.RecoveryCodes.UserID.EQ(userID),
+		model.UpdateWhere.RecoveryCodes.DelState.EQ(entity.DelStateActive),
+	).Exec(ctx, exec); err != nil {
+		return errors.Wrap(err, "failed to invalidate recovery codes")
+	}
+	for _, recoveryCode := range recoveryCodes {
+		if _, err := model.RecoveryCodes.Insert(&model.RecoveryCodeSetter{
+			UserID:   lo.ToPtr(userID),
+			CodeHash: lo.ToPtr(hashSecret(normalizeRecoveryCode(recoveryCode))),
+			UseTime:  lo.ToPtr(time.Time{}),
+		}).Exec(ctx, exec); err != nil {
+			return errors.Wrap(err, "failed to insert recovery code")
+		}
+	}
+	return nil
+}
+
+// generateRecoveryCodes returns codes like abcd-efgh
+func generateRecoveryCodes() ([]string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, errors.Wrap(err, "failed to generate recovery code")
+		}
+		code := strings.ToLower(encoding.EncodeToString(raw))
+		recoveryCodes = append(recoveryCodes, code[:len(code)/2]+"-"+code[len(code)/2:])
+	}
+	return recoveryCodes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-project-startup/internal/pkg/totp"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestAuthService_TOTP(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+	clock := time.Now()
+	authSrv.now = func() time.Time {
+		return clock
+	}
+	code := func(secret string) string {
+		c, err := totp.Code(secret, totp.Step(clock))
+		require.Nil(t, err)
+		return c
+	}
+
+	ctx := sidecar.BackgroundContext()
+	alice, err := authSrv.RegisterByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+
+	enrollment, err := authSrv.EnrollTOTP(ctx.Ctx, alice.UserID)
+	require.Nil(t, err)
+	require.Contains(t, enrollment.URI, enrollment.Secret)
+
+	_, err = authSrv.ConfirmTOTP(ctx.Ctx, alice.UserID, enrollment.Secret, "12345")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrMFACode), errcode.DecodeError(err))
+	_, err = authSrv.ConfirmTOTP(ctx.Ctx, alice.UserID, "AAAA", code(enrollment.Secret))
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+	recoveryCodes, err := authSrv.ConfirmTOTP(ctx.Ctx, alice.UserID, enrollment.Secret, code(enrollment.Secret))
+	require.Nil(t, err)
+	require.Len(t, recoveryCodes, recoveryCodeCount)
+
+	_, err = authSrv.EnrollTOTP(ctx.Ctx, alice.UserID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountExists), errcode.DecodeError(err))
+
+	// the first factor only returns a mfa token, which is not an access token
+	login, err := authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	require.NotEmpty(t, login.MFAToken)
+	require.Empty(t, login.Token)
+	_, err = tokenSrv.Verify(ctx.Ctx, login.MFAToken)
+	require.NotNil(t, err)
+
+	// the code used to confirm can not be replayed
+	_, err = authSrv.VerifyMFA(ctx.Ctx, login.MFAToken, code(enrollment.Secret), "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrMFACode), errcode.DecodeError(err))
+
+	clock = clock.Add(totp.Period)
+	verified, err := authSrv.VerifyMFA(ctx.Ctx, login.MFAToken, code(enrollment.Secret), "")
+	require.Nil(t, err)
+	require.Equal(t, alice.UserID, verified.UserID)
+	_, err = tokenSrv.Verify(ctx.Ctx, verified.Token)
+	require.Nil(t, err)
+	_, err = authSrv.VerifyMFA(ctx.Ctx, login.MFAToken, code(enrollment.Secret), "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrMFACode), errcode.DecodeError(err))
+
+	// recovery codes are one-time and case insensitive
+	_, err = authSrv.VerifyMFA(ctx.Ctx, login.MFAToken, strings.ToUpper(recoveryCodes[0]), "")
+	require.Nil(t, err)
+	_, err = authSrv.VerifyMFA(ctx.Ctx, login.MFAToken, recoveryCodes[0], "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrMFACode), errcode.DecodeError(err))
+
+	// totp is not a login method
+	userAuths, err := authSrv.ListUserAuths(ctx.Ctx, alice.UserID)
+	require.Nil(t, err)
+	require.Len(t, userAuths, 2)
+	for _, userAuth := range userAuths {
+		err = authSrv.UnlinkAuth(ctx.Ctx, alice.UserID, userAuth.ID)
+		if userAuth.AuthType == entity.AuthTypeTOTP {
+			require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+		} else {
+			require.Equal(t, errcode.DecodeError(cerrcode.ErrLastAuth), errcode.DecodeError(err))
+		}
+	}
+
+	clock = clock.Add(totp.Period)
+	newRecoveryCodes, err := authSrv.RegenerateRecoveryCodes(ctx.Ctx, alice.UserID, code(enrollment.Secret), "")
+	require.Nil(t, err)
+	_, err = authSrv.VerifyMFA(ctx.Ctx, login.MFAToken, recoveryCodes[1], "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrMFACode), errcode.DecodeError(err))
+
+	require.Nil(t, authSrv.DisableTOTP(ctx.Ctx, alice.UserID, newRecoveryCodes[0], ""))
+	auditLogs, _, err := auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Action: entity.AuditActionUserTOTPDisable}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 1)
+	require.Equal(t, entity.AuditTargetUser(alice.UserID), auditLogs[0].Target)
+	login, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	require.Empty(t, login.MFAToken)
+	require.NotEmpty(t, login.Token)
+}
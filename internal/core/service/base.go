@@ -3,5 +3,5 @@ package service
 import "github.com/zunkk/go-sidecar/frame"
 
 func init() {
-	frame.RegisterComponents(NewUserService)
+	frame.RegisterComponents(NewUserService, NewTokenService, NewAuthService, NewPermissionService, NewAPIKeyService, NewAuditService)
 }
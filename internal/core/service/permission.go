@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	glog "github.com/zunkk/go-sidecar/log"
+)
+
+var permissionLog = glog.WithModule("permission")
+
+// PermissionService is the RBAC registry, role permissions are cached in memory,
+// reloaded whenever they are changed by this service and periodically for the changes of the other nodes
+type PermissionService struct {
+	sidecar      *base.CustomSidecar
+	sqlConnector *dao.SQLConnector
+	db           bob.Executor
+	auditSrv     *AuditService
+
+	lock sync.RWMutex
+	// role -> permission set
+	rolePermissions map[string]map[string]struct{}
+}
+
+func NewPermissionService(sidecar *base.CustomSidecar, sqlConnector *dao.SQLConnector, auditSrv *AuditService) (*PermissionService, error) {
+	s := &PermissionService{
+		sidecar:         sidecar,
+		sqlConnector:    sqlConnector,
+		db:              sqlConnector.Executor,
+		auditSrv:        auditSrv,
+		rolePermissions: map[string]map[string]struct{}{},
+	}
+	sidecar.RegisterLifecycleHook(s)
+	return s, nil
+}
+
+func (s *PermissionService) ComponentName() string {
+	return "permission-service"
+}
+
+func (s *PermissionService) Start() error {
+	if err := s.syncBuiltinPermissions(s.sidecar.Ctx); err != nil {
+		return err
+	}
+	if err := s.reload(s.sidecar.Ctx); err != nil {
+		return err
+	}
+	interval := s.sidecar.Repo.Cfg.Auth.PermissionReloadInterval.ToDuration()
+	if interval == 0 {
+		return nil
+	}
+	s.sidecar.SafeGoPersistentTask(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.sidecar.Ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			// the cache is kept on failure and the next tick retries
+			if err := s.reload(s.sidecar.Ctx); err != nil {
+				permissionLog.Warn("Failed to reload role permissions", "err", err)
+			}
+		}
+	})
+	return nil
+}
+
+func (s *PermissionService) Stop() error {
+	return nil
+}
+
+// HasPermission reports whether the role is granted the permission, admin owns all permissions
+func (s *PermissionService) HasPermission(role string, permission string) bool {
+	if role == entity.UserRoleAdmin {
+		return true
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.rolePermissions[role][permission]
+	return ok
+}
+
+func (s *PermissionService) ListPermissions(ctx context.Context) (model.PermissionSlice, error) {
+	return model.Permissions.Query(
+		model.SelectWhere.Permissions.DelState.EQ(entity.DelStateActive),
+		sm.OrderBy(model.PermissionColumns.Name),
+	).All(ctx, s.db)
+}
+
+func (s *PermissionService) ListRolePermissions(ctx context.Context, role string) ([]string, error) {
+	rolePermissions, err := model.RolePermissions.Query(
+		model.SelectWhere.RolePermissions.Role.EQ(role),
+		model.SelectWhere.RolePermissions.DelState.EQ(entity.DelStateActive),
+		sm.OrderBy(model.RolePermissionColumns.Permission),
+	).All(ctx, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query role permissions")
+	}
+	return lo.Map(rolePermissions, func(item *model.RolePermission, _ int) string {
+		return item.Permission
+	}), nil
+}
+
+func (s *PermissionService) GrantRolePermission(ctx context.Context, role string, permission string) error {
+	if role == "" || role == entity.UserRoleAdmin {
+		return cerrcode.ErrRequestParameter.Wrap("role is empty or admin")
+	}
+	permissionExists, err := model.Permissions.Query(
+		model.SelectWhere.Permissions.Name.EQ(permission),
+		model.SelectWhere.Permissions.DelState.EQ(entity.DelStateActive),
+	).Exists(ctx, s.db)
+	if err != nil {
+		return errors.Wrap(err, "failed to query permission")
+	}
+	if !permissionExists {
+		return cerrcode.ErrRequestParameter.Wrap("unknown permission: " + permission)
+	}
+
+	// the cache is reloaded once the outermost transaction commits, so a rollback of the caller leaves it untouched
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		rolePermission, err := model.RolePermissions.Query(
+			model.SelectWhere.RolePermissions.Role.EQ(role),
+			model.SelectWhere.RolePermissions.Permission.EQ(permission),
+		).One(ctx, dbTX)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return errors.Wrap(err, "failed to query role permission")
+			}
+			if _, err := model.RolePermissions.Insert(&model.RolePermissionSetter{
+				Role:       lo.ToPtr(role),
+				Permission: lo.ToPtr(permission),
+			}).Exec(ctx, dbTX); err != nil {
+				return errors.Wrap(err, "failed to insert role permission")
+			}
+			return s.rolePermissionChanged(ctx, dbTX, AuditEntry{
+				Action: entity.AuditActionRolePermissionGrant,
+				Target: entity.AuditTargetRole(role),
+				After:  map[string]any{"permission": permission},
+			})
+		}
+		if rolePermission.DelState == entity.DelStateActive {
+			return nil
+		}
+		// reactivate the revoked row, (role, permission) is unique
+		if _, err := model.RolePermissions.Update(
+			model.RolePermissionSetter{
+				DeleteTime: lo.ToPtr(time.Time{}),
+				DelState:   lo.ToPtr(entity.DelStateActive),
+			}.UpdateMod(),
+			model.UpdateWhere.RolePermissions.ID.EQ(rolePermission.ID),
+		).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to update role permission")
+		}
+		return s.rolePermissionChanged(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionRolePermissionGrant,
+			Target: entity.AuditTargetRole(role),
+			After:  map[string]any{"permission": permission},
+		})
+	})
+}
+
+func (s *PermissionService) RevokeRolePermission(ctx context.Context, role string, permission string) error {
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		revoked, err := model.RolePermissions.Update(
+			model.RolePermissionSetter{
+				DeleteTime: lo.ToPtr(time.Now().UTC()),
+				DelState:   lo.ToPtr(entity.DelStateDeleted),
+			}.UpdateMod(),
+			model.UpdateWhere.RolePermissions.Role.EQ(role),
+			model.UpdateWhere.RolePermissions.Permission.EQ(permission),
+			model.UpdateWhere.RolePermissions.DelState.EQ(entity.DelStateActive),
+		).Exec(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to revoke role permission")
+		}
+		if revoked == 0 {
+			return nil
+		}
+		return s.rolePermissionChanged(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionRolePermissionRevoke,
+			Target: entity.AuditTargetRole(role),
+			Before: map[string]any{"permission": permission},
+		})
+	})
+}
+
+// rolePermissionChanged audits the grant or the revocation and reloads the cache once the transaction commits
+func (s *PermissionService) rolePermissionChanged(ctx context.Context, exec bob.Executor, entry AuditEntry) error {
+	if err := s.auditSrv.RecordTx(ctx, exec, entry); err != nil {
+		return err
+	}
+	return dao.AfterCommit(ctx, s.reload)
+}
+
+func (s *PermissionService) syncBuiltinPermissions(ctx context.Context) error {
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		existPermissions, err := model.Permissions.Query().All(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to query permissions")
+		}
+		existPermissionMap := lo.KeyBy(existPermissions, func(item *model.Permission) string {
+			return item.Name
+		})
+
+		for _, permission := range entity.BuiltinPermissions {
+			if existPermission, ok := existPermissionMap[permission.Name]; ok {
+				if existPermission.Description == permission.Description && existPermission.DelState == entity.DelStateActive {
+					continue
+				}
+				if _, err := model.Permissions.Update(
+					model.PermissionSetter{
+						DeleteTime:  lo.ToPtr(time.Time{}),
+						DelState:    lo.ToPtr(entity.DelStateActive),
+						Description: lo.ToPtr(permission.Description),
+					}.UpdateMod(),
+					model.UpdateWhere.Permissions.ID.EQ(existPermission.ID),
+				).Exec(ctx, dbTX); err != nil {
+					return errors.Wrapf(err, "failed to update permission %s", permission.Name)
+				}
+				continue
+			}
+
+			if _, err := model.Permissions.Insert(&model.PermissionSetter{
+				Name:        lo.ToPtr(permission.Name),
+				Description: lo.ToPtr(permission.Description),
+			}).Exec(ctx, dbTX); err != nil {
+				return errors.Wrapf(err, "failed to insert permission %s", permission.Name)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *PermissionService) reload(ctx context.Context) error {
+	rolePermissions, err := model.RolePermissions.Query(
+		model.SelectWhere.RolePermissions.DelState.EQ(entity.DelStateActive),
+	).All(ctx, s.db)
+	if err != nil {
+		return errors.Wrap(err, "failed to load role permissions")
+	}
+
+	rolePermissionMap := map[string]map[string]struct{}{}
+	for _, rolePermission := range rolePermissions {
+		if _, ok := rolePermissionMap[rolePermission.Role]; !ok {
+			rolePermissionMap[rolePermission.Role] = map[string]struct{}{}
+		}
+		rolePermissionMap[rolePermission.Role][rolePermission.Permission] = struct{}{}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rolePermissions = rolePermissionMap
+	return nil
+}
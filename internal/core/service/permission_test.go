@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+	"github.com/zunkk/go-sidecar/repo"
+)
+
+func TestPermissionService_GrantAndRevoke(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	permissionSrv, err := NewPermissionService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	require.Nil(t, permissionSrv.Start())
+	// sync is idempotent
+	require.Nil(t, permissionSrv.Start())
+
+	ctx := sidecar.BackgroundContext()
+	permissions, err := permissionSrv.ListPermissions(ctx.Ctx)
+	require.Nil(t, err)
+	require.Len(t, permissions, len(entity.BuiltinPermissions))
+
+	require.True(t, permissionSrv.HasPermission(entity.UserRoleAdmin, entity.PermissionUserWrite))
+	require.False(t, permissionSrv.HasPermission(entity.UserRoleNormal, entity.PermissionUserWrite))
+
+	err = permissionSrv.GrantRolePermission(ctx.Ctx, entity.UserRoleNormal, "unknown:write")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	require.Nil(t, permissionSrv.GrantRolePermission(ctx.Ctx, entity.UserRoleNormal, entity.PermissionUserWrite))
+	require.True(t, permissionSrv.HasPermission(entity.UserRoleNormal, entity.PermissionUserWrite))
+	require.False(t, permissionSrv.HasPermission(entity.UserRoleNormal, entity.PermissionUserRead))
+
+	rolePermissions, err := permissionSrv.ListRolePermissions(ctx.Ctx, entity.UserRoleNormal)
+	require.Nil(t, err)
+	require.Equal(t, []string{entity.PermissionUserWrite}, rolePermissions)
+
+	require.Nil(t, permissionSrv.RevokeRolePermission(ctx.Ctx, entity.UserRoleNormal, entity.PermissionUserWrite))
+	require.False(t, permissionSrv.HasPermission(entity.UserRoleNormal, entity.PermissionUserWrite))
+
+	// grant again after revoke
+	require.Nil(t, permissionSrv.GrantRolePermission(ctx.Ctx, entity.UserRoleNormal, entity.PermissionUserWrite))
+	require.True(t, permissionSrv.HasPermission(entity.UserRoleNormal, entity.PermissionUserWrite))
+
+	auditLogs, _, err := auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Target: entity.AuditTargetRole(entity.UserRoleNormal)}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 3)
+	require.Equal(t, entity.AuditActionRolePermissionGrant, auditLogs[0].Action)
+	require.Equal(t, entity.AuditActionRolePermissionRevoke, auditLogs[1].Action)
+	require.JSONEq(t, `{"before":{"permission":"user:write"},"after":{}}`, auditLogs[1].Diff)
+}
+
+func TestPermissionService_PeriodicReload(t *testing.T) {
+	// the reload stops with the test, the context is set before any task reads it
+	sidecar := base.NewMockCustomSidecar(t)
+	nodeCtx, cancel := context.WithCancel(sidecar.Ctx)
+	sidecar.Ctx = nodeCtx
+	sqlConnector := dao.NewMockSQLConnector(t, sidecar)
+	// cleaned up before the db is closed
+	t.Cleanup(cancel)
+	sidecar.Repo.Cfg.Auth.PermissionReloadInterval = repo.Duration(20 * time.Millisecond)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	// two nodes sharing the db
+	node, err := NewPermissionService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	require.Nil(t, node.Start())
+	otherNode, err := NewPermissionService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	require.Nil(t, otherNode.Start())
+
+	ctx := sidecar.BackgroundContext()
+	require.Nil(t, node.GrantRolePermission(ctx.Ctx, entity.UserRoleNormal, entity.PermissionUserWrite))
+	require.True(t, node.HasPermission(entity.UserRoleNormal, entity.PermissionUserWrite))
+	require.Eventually(t, func() bool {
+		return otherNode.HasPermission(entity.UserRoleNormal, entity.PermissionUserWrite)
+	}, time.Second, 10*time.Millisecond)
+
+	// the revocation takes effect on the other node within the reload interval
+	require.Nil(t, node.RevokeRolePermission(ctx.Ctx, entity.UserRoleNormal, entity.PermissionUserWrite))
+	require.False(t, node.HasPermission(entity.UserRoleNormal, entity.PermissionUserWrite))
+	require.Eventually(t, func() bool {
+		return !otherNode.HasPermission(entity.UserRoleNormal, entity.PermissionUserWrite)
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+
+	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-project-startup/internal/pkg/jwtkey"
+	"github.com/zunkk/go-sidecar/repo"
+)
+
+const refreshTokenBytes = 32
+
+// AuthToken is the result of a login, only UserID and MFAToken are set if the second factor is required
+type AuthToken struct {
+	UserID            int64
+	Token             string
+	ExpireTime        time.Time
+	RefreshToken      string
+	RefreshExpireTime time.Time
+	MFAToken          string
+	MFAExpireTime     time.Time
+}
+
+// TokenService issues access tokens (JWT) and refresh tokens,
+// refresh tokens issued by one login share a family so a reused token can revoke the whole session
+type TokenService struct {
+	sidecar      *base.CustomSidecar
+	sqlConnector *dao.SQLConnector
+	db           bob.Executor
+	auditSrv     *AuditService
+
+	algorithm    string
+	validMethods []string
+	keyLock      sync.RWMutex
+	// nil when signing with the HMAC key
+	keySet *jwtkey.KeySet
+}
+
+func NewTokenService(sidecar *base.CustomSidecar, sqlConnector *dao.SQLConnector, auditSrv *AuditService) (*TokenService, error) {
+	s := &TokenService{
+		sidecar:      sidecar,
+		sqlConnector: sqlConnector,
+		db:           sqlConnector.Executor,
+		auditSrv:     auditSrv,
+		algorithm:    sidecar.Repo.Cfg.Auth.JWTSigningAlgorithm,
+	}
+	switch s.algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		if sidecar.Repo.Cfg.HTTP.JWTTokenHMACKey == "" {
+			return nil, errors.New("http.jwt_token_hmac_key is required by HS256")
+		}
+		s.validMethods = []string{jwt.SigningMethodHS256.Alg()}
+	case jwt.SigningMethodEdDSA.Alg(), jwt.SigningMethodRS256.Alg():
+		keySet, err := jwtkey.Load(filepath.Join(sidecar.Repo.RepoPath, jwtkey.DirName), s.algorithm, s.keyRetention())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load jwt keys")
+		}
+		s.keySet = keySet
+		// retired keys may use another algorithm
+		s.validMethods = []string{jwt.SigningMethodEdDSA.Alg(), jwt.SigningMethodRS256.Alg()}
+	default:
+		return nil, errors.Errorf("unsupported auth.jwt_signing_algorithm: %s", s.algorithm)
+	}
+	return s, nil
+}
+
+// Generate signs an access token for the user
+func (s *TokenService) Generate(userID int64, role string, tokenGeneration int64) (token string, expireTime time.Time, err error) {
+	now := time.Now().UTC()
+	expireTime = now.Add(s.sidecar.Repo.Cfg.HTTP.JWTTokenValidDuration.ToDuration())
+	claims := entity.CustomClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    repo.AppName,
+			Subject:   strconv.FormatInt(userID, 10),
+			ExpiresAt: jwt.NewNumericDate(expireTime),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        strconv.FormatInt(int64(s.sidecar.UUIDGenerator.Generate()), 10),
+		},
+		Role:            role,
+		TokenGeneration: tokenGeneration,
+	}
+	token, err = s.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expireTime, nil
+}
+
+// GenerateMFAToken signs the token of a login waiting for the second factor, it is not an access token
+func (s *TokenService) GenerateMFAToken(userID int64, tokenGeneration int64) (token string, expireTime time.Time, err error) {
+	now := time.Now().UTC()
+	expireTime = now.Add(s.sidecar.Repo.Cfg.Auth.MFATokenValidDuration.ToDuration())
+	token, err = s.sign(entity.CustomClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    repo.AppName,
+			Subject:   strconv.FormatInt(userID, 10),
+			ExpiresAt: jwt.NewNumericDate(expireTime),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        strconv.FormatInt(int64(s.sidecar.UUIDGenerator.Generate()), 10),
+		},
+		TokenGeneration: tokenGeneration,
+		MFAPending:      true,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expireTime, nil
+}
+
+// ParseMFAToken returns the claims of a token signed by GenerateMFAToken
+func (s *TokenService) ParseMFAToken(token string) (*entity.CustomClaims, error) {
+	claims, err := s.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.MFAPending {
+		return nil, errors.New("not a mfa token")
+	}
+	return claims, nil
+}
+
+func (s *TokenService) sign(claims entity.CustomClaims) (string, error) {
+	var t *jwt.Token
+	var signingKey any
+	if s.keySet == nil {
+		t = jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		signingKey = []byte(s.sidecar.Repo.Cfg.HTTP.JWTTokenHMACKey)
+	} else {
+		s.keyLock.RLock()
+		key := s.keySet.Active()
+		s.keyLock.RUnlock()
+		t = jwt.NewWithClaims(key.SigningMethod(), claims)
+		t.Header["kid"] = key.KID
+		signingKey = key.PrivateKey()
+	}
+	token, err := t.SignedString(signingKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign token")
+	}
+	return token, nil
+}
+
+// Parse verifies the token signature and expiry, then returns its claims
+func (s *TokenService) Parse(token string) (*entity.CustomClaims, error) {
+	var claims entity.CustomClaims
+	_, err := jwt.ParseWithClaims(token, &claims, s.verificationKey, jwt.WithValidMethods(s.validMethods), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token data invalid: subject is empty")
+	}
+	return &claims, nil
+}
+
+// RotateKey generates a new signing key, tokens signed by the previous key stay valid until they expire
+func (s *TokenService) RotateKey() (*jwtkey.Key, error) {
+	if s.keySet == nil {
+		return nil, cerrcode.ErrRequestParameter.Wrap("HS256 signing key can not be rotated")
+	}
+	s.keyLock.Lock()
+	defer s.keyLock.Unlock()
+	return s.keySet.Rotate(s.algorithm, s.keyRetention())
+}
+
+// JWKS returns the public keys which can verify the access tokens
+func (s *TokenService) JWKS() jwtkey.JWKSet {
+	if s.keySet == nil {
+		return jwtkey.JWKSet{Keys: []jwtkey.JWK{}}
+	}
+	s.keyLock.RLock()
+	defer s.keyLock.RUnlock()
+	return s.keySet.JWKS()
+}
+
+func (s *TokenService) verificationKey(t *jwt.Token) (any, error) {
+	if s.keySet == nil {
+		return []byte(s.sidecar.Repo.Cfg.HTTP.JWTTokenHMACKey), nil
+	}
+	kid, _ := t.Header["kid"].(string)
+	s.keyLock.RLock()
+	key := s.keySet.Find(kid)
+	s.keyLock.RUnlock()
+	if key == nil {
+		return nil, errors.Errorf("unknown key id: %s", kid)
+	}
+	if t.Method.Alg() != key.Algorithm {
+		return nil, errors.Errorf("key %s does not match algorithm %s", kid, t.Method.Alg())
+	}
+	return key.PublicKey(), nil
+}
+
+// retired keys are kept as long as the tokens signed by them may be valid
+func (s *TokenService) keyRetention() time.Duration {
+	return s.sidecar.Repo.Cfg.HTTP.JWTTokenValidDuration.ToDuration()
+}
+
+// Verify parses the token, then checks the user is still active and the token is not revoked by LogoutAll
+func (s *TokenService) Verify(ctx context.Context, token string) (*entity.CustomClaims, error) {
+	claims, err := s.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.MFAPending {
+		return nil, errors.New("mfa token is not an access token")
+	}
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "token data invalid: subject is not user id")
+	}
+	user, err := model.Users.Query(
+		model.SelectWhere.Users.ID.EQ(userID),
+		model.SelectWhere.Users.DelState.EQ(entity.DelStateActive),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, errors.Wrap(err, "failed to query user")
+	}
+	if user.TokenGeneration != claims.TokenGeneration {
+		return nil, errors.New("token has been revoked")
+	}
+	return claims, nil
+}
+
+// Issue signs an access token and creates the refresh token of a new login session
+func (s *TokenService) Issue(ctx context.Context, exec bob.Executor, user *model.User) (*AuthToken, error) {
+	return s.issue(ctx, exec, user, 0)
+}
+
+// Refresh rotates the refresh token and returns a new token pair,
+// presenting an already rotated refresh token revokes its whole login session
+func (s *TokenService) Refresh(ctx context.Context, refreshToken string) (*AuthToken, error) {
+	var authToken *AuthToken
+	var reused bool
+	err := s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		// the transaction may be retried, so the results of a failed attempt must not leak into the next one
+		authToken, reused = nil, false
+		old, err := model.RefreshTokens.Query(
+			model.SelectWhere.RefreshTokens.TokenHash.EQ(hashSecret(refreshToken)),
+		).One(ctx, dbTX)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return cerrcode.ErrAuthCode.Wrap("refresh token not found")
+			}
+			return errors.Wrap(err, "failed to query refresh token")
+		}
+		if old.DelState != entity.DelStateActive {
+			return cerrcode.ErrAuthCode.Wrap("refresh token has been revoked")
+		}
+
+		now := time.Now().UTC()
+		if !old.RotateTime.IsZero() {
+			// the family must be revoked even though an error is returned, so commit instead of rollback
+			reused = true
+			return s.revokeRefreshTokens(ctx, dbTX, now, model.UpdateWhere.RefreshTokens.FamilyID.EQ(old.FamilyID))
+		}
+		if now.After(old.ExpireTime) {
+			return cerrcode.ErrAuthCode.Wrap("refresh token expired")
+		}
+
+		// the version check makes concurrent refreshes with the same token count as reuse
+		rotated, err := model.RefreshTokens.Update(
+			model.RefreshTokenSetter{
+				RotateTime: lo.ToPtr(now),
+			}.UpdateMod(),
+			model.UpdateWhere.RefreshTokens.ID.EQ(old.ID),
+			model.UpdateWhere.RefreshTokens.Version.EQ(old.Version),
+		).Exec(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to rotate refresh token")
+		}
+		if rotated == 0 {
+			reused = true
+			return s.revokeRefreshTokens(ctx, dbTX, now, model.UpdateWhere.RefreshTokens.FamilyID.EQ(old.FamilyID))
+		}
+
+		user, err := model.Users.Query(
+			model.SelectWhere.Users.ID.EQ(old.UserID),
+			model.SelectWhere.Users.DelState.EQ(entity.DelStateActive),
+		).One(ctx, dbTX)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return cerrcode.ErrAuthCode.Wrap("user not found")
+			}
+			return errors.Wrap(err, "failed to query user")
+		}
+
+		authToken, err = s.issue(ctx, dbTX, user, old.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, cerrcode.ErrRefreshTokenReuse.Wrap("login session has been revoked")
+	}
+	return authToken, nil
+}
+
+// Logout revokes the login session which the refresh token belongs to
+func (s *TokenService) Logout(ctx context.Context, userID int64, refreshToken string) error {
+	old, err := model.RefreshTokens.Query(
+		model.SelectWhere.RefreshTokens.TokenHash.EQ(hashSecret(refreshToken)),
+		model.SelectWhere.RefreshTokens.UserID.EQ(userID),
+	).One(ctx, s.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return cerrcode.ErrAuthCode.Wrap("refresh token not found")
+		}
+		return errors.Wrap(err, "failed to query refresh token")
+	}
+	return s.revokeRefreshTokens(ctx, s.db, time.Now().UTC(), model.UpdateWhere.RefreshTokens.FamilyID.EQ(old.FamilyID))
+}
+
+// LogoutAll revokes all refresh tokens of the user and invalidates all issued access tokens
+func (s *TokenService) LogoutAll(ctx context.Context, userID int64) error {
+	return s.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		if err := s.RevokeAll(ctx, dbTX, userID); err != nil {
+			return err
+		}
+		return s.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Action: entity.AuditActionUserLogoutAll,
+			Target: entity.AuditTargetUser(userID),
+		})
+	})
+}
+
+// RevokeAll is LogoutAll running with the executor, so it can join the transaction of the caller
+func (s *TokenService) RevokeAll(ctx context.Context, exec bob.Executor, userID int64) error {
+	now := time.Now().UTC()
+	if _, err := model.Users.Update(
+		um.SetCol(model.ColumnNames.Users.TokenGeneration).To(psql.Quote(model.ColumnNames.Users.TokenGeneration).OP("+", psql.Arg(1))),
+		model.UpdateWhere.Users.ID.EQ(userID),
+	).Exec(ctx, exec); err != nil {
+		return errors.Wrap(err, "failed to increase user token generation")
+	}
+	return s.revokeRefreshTokens(ctx, exec, now, model.UpdateWhere.RefreshTokens.UserID.EQ(userID))
+}
+
+func (s *TokenService) issue(ctx context.Context, exec bob.Executor, user *model.User, familyID int64) (*AuthToken, error) {
+	token, expireTime, err := s.Generate(user.ID, user.Role, user.TokenGeneration)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTokenRaw := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(refreshTokenRaw); err != nil {
+		return nil, errors.Wrap(err, "failed to generate refresh token")
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(refreshTokenRaw)
+
+	now := time.Now().UTC()
+	refreshExpireTime := now.Add(s.sidecar.Repo.Cfg.Auth.RefreshTokenValidDuration.ToDuration())
+	id := int64(s.sidecar.UUIDGenerator.Generate())
+	if familyID == 0 {
+		familyID = id
+	}
+	if _, err := model.RefreshTokens.Insert(&model.RefreshTokenSetter{
+		ID:         lo.ToPtr(id),
+		UserID:     lo.ToPtr(user.ID),
+		FamilyID:   lo.ToPtr(familyID),
+		TokenHash:  lo.ToPtr(hashSecret(refreshToken)),
+		ExpireTime: lo.ToPtr(refreshExpireTime),
+		RotateTime: lo.ToPtr(time.Time{}),
+	}).Exec(ctx, exec); err != nil {
+		return nil, errors.Wrap(err, "failed to insert refresh token")
+	}
+
+	return &AuthToken{
+		UserID:            user.ID,
+		Token:             token,
+		ExpireTime:        expireTime,
+		RefreshToken:      refreshToken,
+		RefreshExpireTime: refreshExpireTime,
+	}, nil
+}
+
+func (s *TokenService) revokeRefreshTokens(ctx context.Context, exec bob.Executor, now time.Time, where bob.Mod[*dialect.UpdateQuery]) error {
+	if _, err := model.RefreshTokens.Update(
+		model.RefreshTokenSetter{
+			DeleteTime: lo.ToPtr(now),
+			DelState:   lo.ToPtr(entity.DelStateDeleted),
+		}.UpdateMod(),
+		where,
+		model.UpdateWhere.RefreshTokens.DelState.EQ(entity.DelStateActive),
+	).Exec(ctx, exec); err != nil {
+		return errors.Wrap(err, "failed to revoke refresh tokens")
+	}
+	return nil
+}
+
+// hashSecret is used to store refresh tokens and verification codes without the plaintext
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
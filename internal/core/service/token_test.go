@@ -0,0 +1,153 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestTokenService_Refresh(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	first, err := authSrv.RegisterByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	require.NotEmpty(t, first.RefreshToken)
+
+	second, err := tokenSrv.Refresh(ctx.Ctx, first.RefreshToken)
+	require.Nil(t, err)
+	require.Equal(t, first.UserID, second.UserID)
+	require.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	_, err = tokenSrv.Verify(ctx.Ctx, second.Token)
+	require.Nil(t, err)
+
+	// reusing a rotated token revokes the whole session
+	_, err = tokenSrv.Refresh(ctx.Ctx, first.RefreshToken)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRefreshTokenReuse), errcode.DecodeError(err))
+	_, err = tokenSrv.Refresh(ctx.Ctx, second.RefreshToken)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+
+	_, err = tokenSrv.Refresh(ctx.Ctx, "unknown")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+
+	// other sessions are not affected
+	login, err := authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	third, err := tokenSrv.Refresh(ctx.Ctx, login.RefreshToken)
+	require.Nil(t, err)
+
+	require.Nil(t, tokenSrv.Logout(ctx.Ctx, third.UserID, third.RefreshToken))
+	_, err = tokenSrv.Refresh(ctx.Ctx, third.RefreshToken)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+}
+
+func TestTokenService_LogoutAll(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	first, err := authSrv.RegisterByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	second, err := authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+
+	require.Nil(t, tokenSrv.LogoutAll(ctx.Ctx, first.UserID))
+	auditLogs, _, err := auditSrv.ListAuditLogs(ctx.Ctx, AuditFilter{Action: entity.AuditActionUserLogoutAll}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, auditLogs, 1)
+	require.Equal(t, entity.AuditTargetUser(first.UserID), auditLogs[0].Target)
+
+	_, err = tokenSrv.Verify(ctx.Ctx, first.Token)
+	require.NotNil(t, err)
+	_, err = tokenSrv.Refresh(ctx.Ctx, second.RefreshToken)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAuthCode), errcode.DecodeError(err))
+
+	user, err := model.FindUser(ctx.Ctx, sqlConnector.DB, first.UserID)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), user.TokenGeneration)
+
+	third, err := authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	claims, err := tokenSrv.Verify(ctx.Ctx, third.Token)
+	require.Nil(t, err)
+	require.Equal(t, entity.UserRoleNormal, claims.Role)
+	require.Equal(t, int64(1), claims.TokenGeneration)
+}
+
+func TestTokenService_RotateKey(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+
+	oldToken, _, err := tokenSrv.Generate(1, entity.UserRoleNormal, 0)
+	require.Nil(t, err)
+
+	key, err := tokenSrv.RotateKey()
+	require.Nil(t, err)
+	require.Len(t, tokenSrv.JWKS().Keys, 2)
+
+	newToken, _, err := tokenSrv.Generate(1, entity.UserRoleNormal, 0)
+	require.Nil(t, err)
+	parsed, err := jwt.Parse(newToken, func(t *jwt.Token) (any, error) {
+		return key.PublicKey(), nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, key.KID, parsed.Header["kid"])
+
+	// tokens signed by the retired key stay valid
+	_, err = tokenSrv.Parse(oldToken)
+	require.Nil(t, err)
+
+	// a token signed by a restarted service shares the stored keys
+	restarted, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	_, err = restarted.Parse(oldToken)
+	require.Nil(t, err)
+	_, err = restarted.Parse(newToken)
+	require.Nil(t, err)
+}
+
+func TestTokenService_HMAC(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	sidecar.Repo.Cfg.Auth.JWTSigningAlgorithm = jwt.SigningMethodHS256.Alg()
+
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	_, err = NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.NotNil(t, err)
+
+	sidecar.Repo.Cfg.HTTP.JWTTokenHMACKey = "test-hmac-key"
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	token, _, err := tokenSrv.Generate(1, entity.UserRoleNormal, 0)
+	require.Nil(t, err)
+	_, err = tokenSrv.Parse(token)
+	require.Nil(t, err)
+	require.Empty(t, tokenSrv.JWKS().Keys)
+
+	_, err = tokenSrv.RotateKey()
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+}
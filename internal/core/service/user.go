@@ -2,26 +2,92 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
 	"github.com/stephenafamo/bob"
 
 	"github.com/zunkk/go-project-startup/internal/core/dao"
 	"github.com/zunkk/go-project-startup/internal/core/model"
 	"github.com/zunkk/go-project-startup/internal/pkg/base"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+)
+
+const (
+	nicknameMaxLen = 64
+	userInfoMaxLen = 255
 )
 
 type UserService struct {
-	sidecar *base.CustomSidecar
-	db      *bob.DB
+	sidecar      *base.CustomSidecar
+	sqlConnector *dao.SQLConnector
+	db           bob.Executor
+	readDB       bob.Executor
+	tokenSrv     *TokenService
+	auditSrv     *AuditService
+	users        *dao.Repository[*model.User, model.UserSlice, *model.UserSetter]
+	userAuths    *dao.Repository[*model.UserAuth, model.UserAuthSlice, *model.UserAuthSetter]
 }
 
-func NewUserService(sidecar *base.CustomSidecar, sqlConnector *dao.SQLConnector) (*UserService, error) {
+func NewUserService(sidecar *base.CustomSidecar, sqlConnector *dao.SQLConnector, tokenSrv *TokenService, auditSrv *AuditService) (*UserService, error) {
 	return &UserService{
-		sidecar: sidecar,
-		db:      sqlConnector.DB,
+		sidecar:      sidecar,
+		sqlConnector: sqlConnector,
+		db:           sqlConnector.Executor,
+		readDB:       sqlConnector.ReadExecutor,
+		tokenSrv:     tokenSrv,
+		auditSrv:     auditSrv,
+		users:        dao.NewRepository(model.Users),
+		userAuths:    dao.NewRepository(model.UserAuths),
 	}, nil
 }
 
+// QueryByID returns an error wrapping sql.ErrNoRows if the user does not exist or has been deleted,
+// it may read a replica, use dao.ForcePrimary to see the latest writes
 func (d *UserService) QueryByID(ctx context.Context, id int64) (*model.User, error) {
-	return model.FindUser(ctx, d.db, id)
+	return d.users.FindByID(ctx, d.readDB, id)
+}
+
+// UpdateProfile updates the fields that are not nil, version is the version the client read,
+// ErrVersionConflict is returned if the user has been modified since then
+func (d *UserService) UpdateProfile(ctx context.Context, id int64, version int64, nickname *string, info *string) (*model.User, error) {
+	setter := model.UserSetter{}
+	if nickname != nil {
+		trimmed := strings.TrimSpace(*nickname)
+		if trimmed == "" || utf8.RuneCountInString(trimmed) > nicknameMaxLen {
+			return nil, cerrcode.ErrRequestParameter.Wrap("nickname is empty or too long")
+		}
+		setter.Nickname = lo.ToPtr(trimmed)
+	}
+	if info != nil {
+		if utf8.RuneCountInString(*info) > userInfoMaxLen {
+			return nil, cerrcode.ErrRequestParameter.Wrap("info is too long")
+		}
+		setter.Info = info
+	}
+
+	updated, err := d.users.Update(
+		setter.UpdateMod(),
+		model.UpdateWhere.Users.ID.EQ(id),
+		model.UpdateWhere.Users.Version.EQ(version),
+	).Exec(ctx, d.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update user")
+	}
+	// the profile returned must be the one just written
+	user, err := d.QueryByID(dao.ForcePrimary(ctx), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, cerrcode.ErrRequestParameter.Wrap("user not found")
+		}
+		return nil, errors.Wrap(err, "failed to query user")
+	}
+	if updated == 0 {
+		return nil, cerrcode.ErrVersionConflict.Wrap(fmt.Sprintf("version %d is stale, current version is %d", version, user.Version))
+	}
+	return user, nil
 }
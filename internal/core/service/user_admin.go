@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+)
+
+const (
+	userListDefaultLimit = 20
+	userListMaxLimit     = 100
+	roleMaxLen           = 20
+)
+
+// UserFilter is the condition of ListUsers, zero fields are ignored
+type UserFilter struct {
+	// case-insensitive substring of the nickname
+	Nickname       string
+	Role           string
+	CreateTimeFrom time.Time
+	// exclusive
+	CreateTimeTo time.Time
+	// nil lists users of all states
+	DelState *int64
+}
+
+// ListUsers returns the users ordered by id descending, cursor is the id of the last user of the previous page
+// and the returned next cursor is 0 on the last page
+func (d *UserService) ListUsers(ctx context.Context, filter UserFilter, cursor int64, limit int) (model.UserSlice, int64, error) {
+	if limit <= 0 {
+		limit = userListDefaultLimit
+	}
+	if limit > userListMaxLimit {
+		limit = userListMaxLimit
+	}
+	mods := []bob.Mod[*dialect.SelectQuery]{
+		sm.OrderBy(model.UserColumns.ID).Desc(),
+		sm.Limit(limit + 1),
+	}
+	if cursor != 0 {
+		mods = append(mods, model.SelectWhere.Users.ID.LT(cursor))
+	}
+	if filter.Nickname != "" {
+		mods = append(mods, sm.Where(psql.Group(psql.F("lower", model.UserColumns.Nickname)()).Like(psql.Raw(`? ESCAPE '\'`, "%"+escapeLike(strings.ToLower(filter.Nickname))+"%"))))
+	}
+	if filter.Role != "" {
+		mods = append(mods, model.SelectWhere.Users.Role.EQ(filter.Role))
+	}
+	if !filter.CreateTimeFrom.IsZero() {
+		mods = append(mods, model.SelectWhere.Users.CreateTime.GTE(filter.CreateTimeFrom))
+	}
+	if !filter.CreateTimeTo.IsZero() {
+		mods = append(mods, model.SelectWhere.Users.CreateTime.LT(filter.CreateTimeTo))
+	}
+	if filter.DelState != nil {
+		mods = append(mods, model.SelectWhere.Users.DelState.EQ(*filter.DelState))
+	}
+	users, err := d.users.WithDeleted().Query(mods...).All(ctx, d.readDB)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to query users")
+	}
+	if len(users) <= limit {
+		return users, 0, nil
+	}
+	users = users[:limit]
+	return users, users[limit-1].ID, nil
+}
+
+// DeleteUser soft deletes the user with its auths and revokes its login sessions, actor is the caller doing it
+func (d *UserService) DeleteUser(ctx context.Context, actor string, userID int64) error {
+	if actor == strconv.FormatInt(userID, 10) {
+		return cerrcode.ErrRequestParameter.Wrap("can not delete yourself")
+	}
+	return d.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		user, err := d.findUser(ctx, dbTX, userID)
+		if err != nil {
+			return err
+		}
+		if user.DelState != entity.DelStateActive {
+			return cerrcode.ErrRequestParameter.Wrap("user already deleted")
+		}
+		now := time.Now().UTC()
+		deleted, err := d.users.SoftDelete(ctx, dbTX, now,
+			model.UpdateWhere.Users.ID.EQ(userID),
+			model.UpdateWhere.Users.Version.EQ(user.Version),
+		)
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return cerrcode.ErrVersionConflict.Wrap("user modified concurrently")
+		}
+		// restore brings back the auths deleted together with the user by the same delete_time
+		if _, err := d.userAuths.SoftDelete(ctx, dbTX, now, model.UpdateWhere.UserAuths.UserID.EQ(userID)); err != nil {
+			return err
+		}
+		if err := d.tokenSrv.RevokeAll(ctx, dbTX, userID); err != nil {
+			return err
+		}
+		// the revoked api keys are not restored with the user
+		if err := revokeAPIKeysOfCreator(ctx, dbTX, userID, now); err != nil {
+			return err
+		}
+		return d.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Actor:  actor,
+			Action: entity.AuditActionUserDelete,
+			Target: entity.AuditTargetUser(userID),
+			Before: map[string]any{"del_state": entity.DelStateActive},
+			After:  map[string]any{"del_state": entity.DelStateDeleted},
+		})
+	})
+}
+
+// RestoreUser undoes DeleteUser, ErrAccountExists is returned if an auth has been taken by another user since then
+func (d *UserService) RestoreUser(ctx context.Context, actor string, userID int64) error {
+	return d.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		user, err := d.findUser(ctx, dbTX, userID)
+		if err != nil {
+			return err
+		}
+		if user.DelState != entity.DelStateDeleted {
+			return cerrcode.ErrRequestParameter.Wrap("user is not deleted")
+		}
+		userAuths, err := d.userAuths.WithDeleted().Query(
+			model.SelectWhere.UserAuths.UserID.EQ(userID),
+			model.SelectWhere.UserAuths.DelState.EQ(entity.DelStateDeleted),
+			model.SelectWhere.UserAuths.DeleteTime.EQ(user.DeleteTime),
+		).All(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to query user auths")
+		}
+		for _, userAuth := range userAuths {
+			if err := checkUserAuthNotExists(ctx, dbTX, userAuth.AuthType, userAuth.AuthID); err != nil {
+				return err
+			}
+		}
+
+		restored, err := d.users.Restore(ctx, dbTX,
+			model.UpdateWhere.Users.ID.EQ(userID),
+			model.UpdateWhere.Users.Version.EQ(user.Version),
+		)
+		if err != nil {
+			return err
+		}
+		if restored == 0 {
+			return cerrcode.ErrVersionConflict.Wrap("user modified concurrently")
+		}
+		if len(userAuths) != 0 {
+			if _, err := d.userAuths.Restore(ctx, dbTX, model.UpdateWhere.UserAuths.ID.In(lo.Map(userAuths, func(item *model.UserAuth, _ int) int64 {
+				return item.ID
+			})...)); err != nil {
+				return err
+			}
+		}
+		return d.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Actor:  actor,
+			Action: entity.AuditActionUserRestore,
+			Target: entity.AuditTargetUser(userID),
+			Before: map[string]any{"del_state": entity.DelStateDeleted},
+			After:  map[string]any{"del_state": entity.DelStateActive},
+		})
+	})
+}
+
+// SetUserRole changes the role of the user, the issued tokens and the created api keys carry the old role so they are revoked
+func (d *UserService) SetUserRole(ctx context.Context, actor string, userID int64, role string) error {
+	if role == "" || len(role) > roleMaxLen {
+		return cerrcode.ErrRequestParameter.Wrap("role is empty or too long")
+	}
+	if actor == strconv.FormatInt(userID, 10) {
+		return cerrcode.ErrRequestParameter.Wrap("can not change your own role")
+	}
+	return d.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		user, err := d.findUser(ctx, dbTX, userID)
+		if err != nil {
+			return err
+		}
+		if user.DelState != entity.DelStateActive {
+			return cerrcode.ErrRequestParameter.Wrap("user is deleted")
+		}
+		if user.Role == role {
+			return nil
+		}
+		updated, err := d.users.Update(
+			model.UserSetter{
+				Role: lo.ToPtr(role),
+			}.UpdateMod(),
+			model.UpdateWhere.Users.ID.EQ(userID),
+			model.UpdateWhere.Users.Version.EQ(user.Version),
+		).Exec(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to update user role")
+		}
+		if updated == 0 {
+			return cerrcode.ErrVersionConflict.Wrap("user modified concurrently")
+		}
+		if err := d.tokenSrv.RevokeAll(ctx, dbTX, userID); err != nil {
+			return err
+		}
+		if err := revokeAPIKeysOfCreator(ctx, dbTX, userID, time.Now().UTC()); err != nil {
+			return err
+		}
+		return d.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Actor:  actor,
+			Action: entity.AuditActionUserRole,
+			Target: entity.AuditTargetUser(userID),
+			Before: map[string]any{"role": user.Role},
+			After:  map[string]any{"role": role},
+		})
+	})
+}
+
+// findUser returns the user of any del state for the admin operations
+func (d *UserService) findUser(ctx context.Context, exec bob.Executor, userID int64) (*model.User, error) {
+	user, err := d.users.WithDeleted().FindByID(ctx, exec, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, cerrcode.ErrRequestParameter.Wrap("user not found")
+		}
+		return nil, errors.Wrap(err, "failed to query user")
+	}
+	return user, nil
+}
+
+// escapeLike escapes the wildcards of a like pattern with backslash, sqlite has no default escape character so it must be given by ESCAPE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestUserService_AdminManagement(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	userSrv, err := NewUserService(sidecar, sqlConnector, tokenSrv, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	admin, err := authSrv.RegisterByUsername(ctx.Ctx, "admin", "password123", "")
+	require.Nil(t, err)
+	actor := strconv.FormatInt(admin.UserID, 10)
+	var userIDs []int64
+	for _, name := range []string{"alice", "Alicia", "bob", "carol", "dave"} {
+		authToken, err := authSrv.RegisterByUsername(ctx.Ctx, name, "password123", "")
+		require.Nil(t, err)
+		userIDs = append(userIDs, authToken.UserID)
+	}
+
+	// pages are ordered by id descending
+	var listed []int64
+	var cursor int64
+	for {
+		users, nextCursor, err := userSrv.ListUsers(ctx.Ctx, UserFilter{}, cursor, 2)
+		require.Nil(t, err)
+		require.LessOrEqual(t, len(users), 2)
+		for _, user := range users {
+			listed = append(listed, user.ID)
+		}
+		if nextCursor == 0 {
+			break
+		}
+		cursor = nextCursor
+	}
+	require.Equal(t, append(lo.Reverse(append([]int64{}, userIDs...)), admin.UserID), listed)
+
+	users, _, err := userSrv.ListUsers(ctx.Ctx, UserFilter{Nickname: "ALI"}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, users, 2)
+	users, _, err = userSrv.ListUsers(ctx.Ctx, UserFilter{Nickname: "%"}, 0, 0)
+	require.Nil(t, err)
+	require.Empty(t, users)
+	_, err = authSrv.RegisterByUsername(ctx.Ctx, `back\slash`, "password123", "")
+	require.Nil(t, err)
+	users, _, err = userSrv.ListUsers(ctx.Ctx, UserFilter{Nickname: `\`}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, users, 1)
+	users, _, err = userSrv.ListUsers(ctx.Ctx, UserFilter{CreateTimeTo: time.Now().Add(-time.Hour)}, 0, 0)
+	require.Nil(t, err)
+	require.Empty(t, users)
+
+	// role changes revoke the tokens and the api keys carrying the old role
+	aliceID := userIDs[0]
+	aliceLogin, err := authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	aliceRoleAPIKey, err := model.APIKeys.Insert(&model.APIKeySetter{CreatorID: lo.ToPtr(aliceID), Prefix: lo.ToPtr("alice-role")}).One(ctx.Ctx, sqlConnector.Executor)
+	require.Nil(t, err)
+	require.Nil(t, userSrv.SetUserRole(ctx.Ctx, actor, aliceID, "operator"))
+	_, err = tokenSrv.Verify(ctx.Ctx, aliceLogin.Token)
+	require.NotNil(t, err)
+	aliceRoleAPIKey, err = model.FindAPIKey(ctx.Ctx, sqlConnector.DB, aliceRoleAPIKey.ID)
+	require.Nil(t, err)
+	require.Equal(t, entity.DelStateDeleted, aliceRoleAPIKey.DelState)
+	users, _, err = userSrv.ListUsers(ctx.Ctx, UserFilter{Role: "operator"}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, aliceID, users[0].ID)
+	err = userSrv.SetUserRole(ctx.Ctx, actor, admin.UserID, entity.UserRoleNormal)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	// deletion covers the user auths, the login sessions and the api keys
+	aliceLogin, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	aliceAPIKey, err := model.APIKeys.Insert(&model.APIKeySetter{CreatorID: lo.ToPtr(aliceID)}).One(ctx.Ctx, sqlConnector.Executor)
+	require.Nil(t, err)
+	require.Nil(t, userSrv.DeleteUser(ctx.Ctx, actor, aliceID))
+	aliceAPIKey, err = model.FindAPIKey(ctx.Ctx, sqlConnector.DB, aliceAPIKey.ID)
+	require.Nil(t, err)
+	require.Equal(t, entity.DelStateDeleted, aliceAPIKey.DelState)
+	_, err = userSrv.QueryByID(ctx.Ctx, aliceID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = tokenSrv.Verify(ctx.Ctx, aliceLogin.Token)
+	require.NotNil(t, err)
+	_, err = tokenSrv.Refresh(ctx.Ctx, aliceLogin.RefreshToken)
+	require.NotNil(t, err)
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountOrPassword), errcode.DecodeError(err))
+	err = userSrv.DeleteUser(ctx.Ctx, actor, aliceID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+	err = userSrv.DeleteUser(ctx.Ctx, actor, admin.UserID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+
+	users, _, err = userSrv.ListUsers(ctx.Ctx, UserFilter{DelState: lo.ToPtr(entity.DelStateDeleted)}, 0, 0)
+	require.Nil(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, aliceID, users[0].ID)
+
+	require.Nil(t, userSrv.RestoreUser(ctx.Ctx, actor, aliceID))
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	user, err := model.FindUser(ctx.Ctx, sqlConnector.DB, aliceID)
+	require.Nil(t, err)
+	require.Equal(t, entity.DelStateActive, user.DelState)
+	require.True(t, user.DeleteTime.IsZero())
+
+	// the username has been registered again while bob was deleted
+	bobID := userIDs[2]
+	require.Nil(t, userSrv.DeleteUser(ctx.Ctx, actor, bobID))
+	_, err = authSrv.RegisterByUsername(ctx.Ctx, "bob", "password123", "")
+	require.Nil(t, err)
+	err = userSrv.RestoreUser(ctx.Ctx, actor, bobID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrAccountExists), errcode.DecodeError(err))
+}
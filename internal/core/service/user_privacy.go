@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+)
+
+// erasedNickname replaces the nickname of an erased user
+const erasedNickname = "erased_user"
+
+// UserExport is every row tied to a user, including the deleted ones
+type UserExport struct {
+	User          *model.User
+	UserAuths     model.UserAuthSlice
+	RefreshTokens model.RefreshTokenSlice
+	APIKeys       model.APIKeySlice
+	AuditLogs     model.AuditLogSlice
+}
+
+// ExportUser collects the data of the user for a data subject request, the api layer drops the secrets
+func (d *UserService) ExportUser(ctx context.Context, userID int64) (*UserExport, error) {
+	user, err := d.findUser(ctx, d.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	res := &UserExport{User: user}
+	res.UserAuths, err = d.userAuths.WithDeleted().Query(
+		model.SelectWhere.UserAuths.UserID.EQ(userID),
+		sm.OrderBy(model.UserAuthColumns.ID),
+	).All(ctx, d.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query user auths")
+	}
+	res.RefreshTokens, err = model.RefreshTokens.Query(
+		model.SelectWhere.RefreshTokens.UserID.EQ(userID),
+		sm.OrderBy(model.RefreshTokenColumns.ID),
+	).All(ctx, d.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query refresh tokens")
+	}
+	res.APIKeys, err = model.APIKeys.Query(
+		model.SelectWhere.APIKeys.CreatorID.EQ(userID),
+		sm.OrderBy(model.APIKeyColumns.ID),
+	).All(ctx, d.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query api keys")
+	}
+	targets := append(loginSubjectsOfUser(userID, res.UserAuths), entity.AuditTargetUser(userID))
+	res.AuditLogs, err = model.AuditLogs.Query(
+		sm.Where(psql.Or(
+			model.AuditLogColumns.Actor.EQ(psql.Arg(strconv.FormatInt(userID, 10))),
+			model.AuditLogColumns.Target.In(psql.Arg(lo.ToAnySlice(targets)...)),
+		)),
+		sm.OrderBy(model.AuditLogColumns.ID),
+	).All(ctx, d.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query audit logs")
+	}
+	return res, nil
+}
+
+// EraseUser irreversibly removes the personal data of the user in one transaction,
+// the user row is kept anonymized so the rows referring to the user id stay valid
+func (d *UserService) EraseUser(ctx context.Context, actor string, userID int64) error {
+	if actor == strconv.FormatInt(userID, 10) {
+		return cerrcode.ErrRequestParameter.Wrap("can not erase yourself")
+	}
+	return d.sqlConnector.SubmitDBChangesByTransaction(ctx, func(ctx context.Context, dbTX bob.Transaction) error {
+		user, err := d.findUser(ctx, dbTX, userID)
+		if err != nil {
+			return err
+		}
+		if user.DelState == entity.DelStateErased {
+			return cerrcode.ErrRequestParameter.Wrap("user already erased")
+		}
+		userAuths, err := d.userAuths.WithDeleted().Query(
+			model.SelectWhere.UserAuths.UserID.EQ(userID),
+		).All(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to query user auths")
+		}
+
+		now := time.Now().UTC()
+		deleteTime := user.DeleteTime
+		if user.DelState == entity.DelStateActive {
+			deleteTime = now
+		}
+		updated, err := d.users.WithDeleted().Update(
+			model.UserSetter{
+				DeleteTime: lo.ToPtr(deleteTime),
+				DelState:   lo.ToPtr(entity.DelStateErased),
+				Nickname:   lo.ToPtr(erasedNickname),
+				Info:       lo.ToPtr(""),
+				Role:       lo.ToPtr(""),
+			}.UpdateMod(),
+			um.SetCol(model.ColumnNames.Users.TokenGeneration).To(psql.Quote(model.ColumnNames.Users.TokenGeneration).OP("+", psql.Arg(1))),
+			model.UpdateWhere.Users.ID.EQ(userID),
+			model.UpdateWhere.Users.Version.EQ(user.Version),
+		).Exec(ctx, dbTX)
+		if err != nil {
+			return errors.Wrap(err, "failed to anonymize user")
+		}
+		if updated == 0 {
+			return cerrcode.ErrVersionConflict.Wrap("user modified concurrently")
+		}
+
+		// the auth ids are usernames, emails and telegram ids, the tokens are password hashes and totp secrets
+		if _, err := d.userAuths.WithDeleted().HardDelete(ctx, dbTX, model.DeleteWhere.UserAuths.UserID.EQ(userID)); err != nil {
+			return err
+		}
+		if _, err := model.RecoveryCodes.Delete(model.DeleteWhere.RecoveryCodes.UserID.EQ(userID)).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to delete recovery codes")
+		}
+		if _, err := model.RefreshTokens.Delete(model.DeleteWhere.RefreshTokens.UserID.EQ(userID)).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to delete refresh tokens")
+		}
+		if _, err := model.OauthStates.Delete(model.DeleteWhere.OauthStates.UserID.EQ(userID)).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to delete oauth states")
+		}
+		if err := revokeAPIKeysOfCreator(ctx, dbTX, userID, now); err != nil {
+			return err
+		}
+		emails := lo.FilterMap(userAuths, func(item *model.UserAuth, _ int) (string, bool) {
+			return item.AuthID, item.AuthType == entity.AuthTypeEmail
+		})
+		if len(emails) != 0 {
+			if _, err := model.VerificationCodes.Delete(model.DeleteWhere.VerificationCodes.Target.In(emails...)).Exec(ctx, dbTX); err != nil {
+				return errors.Wrap(err, "failed to delete verification codes")
+			}
+		}
+		subjects := loginSubjectsOfUser(userID, userAuths)
+		if _, err := model.LoginAttempts.Delete(
+			model.DeleteWhere.LoginAttempts.Scope.EQ(entity.LoginScopeAccount),
+			model.DeleteWhere.LoginAttempts.Subject.In(subjects...),
+		).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to delete login attempts")
+		}
+		// failed logins are audited by the account, point them to the user id instead
+		if _, err := model.AuditLogs.Update(
+			model.AuditLogSetter{
+				Target: lo.ToPtr(entity.AuditTargetUser(userID)),
+			}.UpdateMod(),
+			model.UpdateWhere.AuditLogs.Target.In(subjects...),
+		).Exec(ctx, dbTX); err != nil {
+			return errors.Wrap(err, "failed to anonymize audit logs")
+		}
+
+		return d.auditSrv.RecordTx(ctx, dbTX, AuditEntry{
+			Actor:  actor,
+			Action: entity.AuditActionUserErase,
+			Target: entity.AuditTargetUser(userID),
+		})
+	})
+}
+
+// loginSubjectsOfUser returns the account subjects of login_attempt, which are also the targets of failed login audit logs
+func loginSubjectsOfUser(userID int64, userAuths model.UserAuthSlice) []string {
+	subjects := lo.Map(userAuths, func(item *model.UserAuth, _ int) string {
+		return item.AuthType + ":" + item.AuthID
+	})
+	return lo.Uniq(append(subjects, entity.AuthTypeTOTP+":"+strconv.FormatInt(userID, 10)))
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
+	"github.com/zunkk/go-project-startup/internal/core/model"
+	"github.com/zunkk/go-project-startup/internal/pkg/entity"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
+)
+
+func TestUserService_ExportAndErase(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	userSrv, err := NewUserService(sidecar, sqlConnector, tokenSrv, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	admin, err := authSrv.RegisterByUsername(ctx.Ctx, "admin", "password123", "")
+	require.Nil(t, err)
+	alice, err := authSrv.RegisterByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "alice", "wrong-password", "")
+	require.NotNil(t, err)
+	_, err = authSrv.LoginByUsername(ctx.Ctx, "admin", "password123", "")
+	require.Nil(t, err)
+
+	export, err := userSrv.ExportUser(ctx.Ctx, alice.UserID)
+	require.Nil(t, err)
+	require.Equal(t, alice.UserID, export.User.ID)
+	require.Len(t, export.UserAuths, 1)
+	require.Equal(t, "alice", export.UserAuths[0].AuthID)
+	require.Len(t, export.RefreshTokens, 2)
+	require.Len(t, export.AuditLogs, 2)
+	require.Equal(t, entity.AuditActionLogin, export.AuditLogs[0].Action)
+	require.Equal(t, entity.AuditActionLoginFailed, export.AuditLogs[1].Action)
+
+	aliceAPIKey, err := model.APIKeys.Insert(&model.APIKeySetter{CreatorID: lo.ToPtr(alice.UserID)}).One(ctx.Ctx, sqlConnector.Executor)
+	require.Nil(t, err)
+
+	actor := strconv.FormatInt(admin.UserID, 10)
+	err = userSrv.EraseUser(ctx.Ctx, actor, admin.UserID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+	require.Nil(t, userSrv.EraseUser(ctx.Ctx, actor, alice.UserID))
+
+	user, err := model.FindUser(ctx.Ctx, sqlConnector.DB, alice.UserID)
+	require.Nil(t, err)
+	require.Equal(t, erasedNickname, user.Nickname)
+	require.Equal(t, entity.DelStateErased, user.DelState)
+	_, err = tokenSrv.Verify(ctx.Ctx, alice.Token)
+	require.NotNil(t, err)
+	aliceAPIKey, err = model.FindAPIKey(ctx.Ctx, sqlConnector.DB, aliceAPIKey.ID)
+	require.Nil(t, err)
+	require.Equal(t, entity.DelStateDeleted, aliceAPIKey.DelState)
+	loginAttempts, err := model.LoginAttempts.Query(
+		model.SelectWhere.LoginAttempts.Subject.EQ(entity.AuthTypeUsername+":alice"),
+	).Count(ctx.Ctx, sqlConnector.DB)
+	require.Nil(t, err)
+	require.Zero(t, loginAttempts)
+
+	export, err = userSrv.ExportUser(ctx.Ctx, alice.UserID)
+	require.Nil(t, err)
+	require.Empty(t, export.UserAuths)
+	require.Empty(t, export.RefreshTokens)
+	require.Len(t, export.AuditLogs, 3)
+	for _, auditLog := range export.AuditLogs {
+		require.NotContains(t, auditLog.Target, "alice")
+	}
+	require.Equal(t, entity.AuditActionUserErase, export.AuditLogs[2].Action)
+	require.Equal(t, actor, export.AuditLogs[2].Actor)
+
+	// the rows of other users are kept
+	export, err = userSrv.ExportUser(ctx.Ctx, admin.UserID)
+	require.Nil(t, err)
+	require.Len(t, export.UserAuths, 1)
+	require.Len(t, export.RefreshTokens, 2)
+
+	// the username is free again
+	_, err = authSrv.RegisterByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	err = userSrv.RestoreUser(ctx.Ctx, actor, alice.UserID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+	err = userSrv.EraseUser(ctx.Ctx, actor, alice.UserID)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+}
@@ -8,41 +8,36 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/zunkk/go-project-startup/internal/core/dao"
+	"github.com/zunkk/go-project-startup/internal/core/mailer"
 	"github.com/zunkk/go-project-startup/internal/core/model"
 	"github.com/zunkk/go-project-startup/internal/pkg/base"
-	"github.com/zunkk/go-sidecar/db/memory"
+	cerrcode "github.com/zunkk/go-project-startup/internal/pkg/errcode"
+	"github.com/zunkk/go-sidecar/errcode"
 )
 
 func PrepareDB(t *testing.T) (*base.CustomSidecar, *dao.SQLConnector) {
 	sidecar := base.NewMockCustomSidecar(t)
-	memoryDB, err := memory.OpenSQLDB()
-	require.Nil(t, err)
-	sqlConnector, err := dao.NewSQLConnectorWithDB(sidecar, memoryDB)
-	require.Nil(t, err)
-	err = sqlConnector.Start()
-	require.Nil(t, err)
-	return sidecar, sqlConnector
+	return sidecar, dao.NewMockSQLConnector(t, sidecar)
 }
 
 func TestUserService_QueryByID(t *testing.T) {
 	sidecar, sqlConnector := PrepareDB(t)
 
-	userSrv, err := NewUserService(sidecar, sqlConnector)
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	userSrv, err := NewUserService(sidecar, sqlConnector, tokenSrv, auditSrv)
 	require.Nil(t, err)
 
 	ctx := sidecar.BackgroundContext()
 	userID := int64(1)
 	now := time.Now()
 	id, err := model.Users.Insert(&model.UserSetter{
-		ID:         lo.ToPtr(userID),
-		CreateTime: lo.ToPtr(now),
-		UpdateTime: lo.ToPtr(now),
-		DeleteTime: lo.ToPtr(time.Time{}),
-		DelState:   lo.ToPtr(int64(0)),
-		Version:    lo.ToPtr(int64(0)),
-		Nickname:   lo.ToPtr("test"),
-		Info:       lo.ToPtr("test"),
-		Role:       lo.ToPtr("test"),
+		ID:       lo.ToPtr(userID),
+		Nickname: lo.ToPtr("test"),
+		Info:     lo.ToPtr("test"),
+		Role:     lo.ToPtr("test"),
 	}).Exec(ctx.Ctx, sqlConnector.DB)
 	require.Nil(t, err)
 	require.Equal(t, userID, id)
@@ -50,8 +45,8 @@ func TestUserService_QueryByID(t *testing.T) {
 	user, err := userSrv.QueryByID(ctx.Ctx, userID)
 	require.Nil(t, err)
 	require.Equal(t, userID, user.ID)
-	require.Equal(t, now.Unix(), user.CreateTime.Unix())
-	require.Equal(t, now.Unix(), user.UpdateTime.Unix())
+	require.WithinDuration(t, now, user.CreateTime, time.Second)
+	require.WithinDuration(t, now, user.UpdateTime, time.Second)
 	require.Equal(t, time.Time{}.Unix(), user.DeleteTime.Unix())
 	require.Equal(t, int64(0), user.DelState)
 	require.Equal(t, int64(0), user.Version)
@@ -59,3 +54,42 @@ func TestUserService_QueryByID(t *testing.T) {
 	require.Equal(t, "test", user.Info)
 	require.Equal(t, "test", user.Role)
 }
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	sidecar, sqlConnector := PrepareDB(t)
+	auditSrv, err := NewAuditService(sidecar, sqlConnector)
+	require.Nil(t, err)
+	tokenSrv, err := NewTokenService(sidecar, sqlConnector, auditSrv)
+	require.Nil(t, err)
+	userSrv, err := NewUserService(sidecar, sqlConnector, tokenSrv, auditSrv)
+	require.Nil(t, err)
+	authSrv, err := NewAuthService(sidecar, sqlConnector, tokenSrv, mailer.NewMemoryMailer(), auditSrv)
+	require.Nil(t, err)
+
+	ctx := sidecar.BackgroundContext()
+	authToken, err := authSrv.RegisterByUsername(ctx.Ctx, "alice", "password123", "")
+	require.Nil(t, err)
+	user, err := userSrv.QueryByID(ctx.Ctx, authToken.UserID)
+	require.Nil(t, err)
+
+	updated, err := userSrv.UpdateProfile(ctx.Ctx, user.ID, user.Version, lo.ToPtr(" Alice "), nil)
+	require.Nil(t, err)
+	require.Equal(t, "Alice", updated.Nickname)
+	require.Equal(t, user.Info, updated.Info)
+	require.Equal(t, user.Version+1, updated.Version)
+
+	// a write based on the version read before is rejected instead of overwriting
+	_, err = userSrv.UpdateProfile(ctx.Ctx, user.ID, user.Version, nil, lo.ToPtr("stale"))
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrVersionConflict), errcode.DecodeError(err))
+
+	updated, err = userSrv.UpdateProfile(ctx.Ctx, user.ID, updated.Version, nil, lo.ToPtr("hello"))
+	require.Nil(t, err)
+	require.Equal(t, "Alice", updated.Nickname)
+	require.Equal(t, "hello", updated.Info)
+	require.Equal(t, user.Version+2, updated.Version)
+
+	_, err = userSrv.UpdateProfile(ctx.Ctx, user.ID, updated.Version, lo.ToPtr("  "), nil)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+	_, err = userSrv.UpdateProfile(ctx.Ctx, user.ID+1, 0, lo.ToPtr("bob"), nil)
+	require.Equal(t, errcode.DecodeError(cerrcode.ErrRequestParameter), errcode.DecodeError(err))
+}
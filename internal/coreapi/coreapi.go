@@ -1,6 +1,7 @@
 package coreapi
 
 import (
+	"github.com/zunkk/go-project-startup/internal/core/dao"
 	"github.com/zunkk/go-project-startup/internal/core/service"
 	"github.com/zunkk/go-sidecar/frame"
 	"github.com/zunkk/go-sidecar/mutex"
@@ -11,11 +12,23 @@ func init() {
 }
 
 type CoreAPI struct {
-	UserService *service.UserService
+	UserService       *service.UserService
+	TokenService      *service.TokenService
+	AuthService       *service.AuthService
+	PermissionService *service.PermissionService
+	APIKeyService     *service.APIKeyService
+	AuditService      *service.AuditService
+	SQLConnector      *dao.SQLConnector
 }
 
-func NewCoreAPI(userSrv *service.UserService) (*CoreAPI, error) {
+func NewCoreAPI(userSrv *service.UserService, tokenSrv *service.TokenService, authSrv *service.AuthService, permissionSrv *service.PermissionService, apiKeySrv *service.APIKeyService, auditSrv *service.AuditService, sqlConnector *dao.SQLConnector) (*CoreAPI, error) {
 	return &CoreAPI{
-		UserService: userSrv,
+		UserService:       userSrv,
+		TokenService:      tokenSrv,
+		AuthService:       authSrv,
+		PermissionService: permissionSrv,
+		APIKeyService:     apiKeySrv,
+		AuditService:      auditSrv,
+		SQLConnector:      sqlConnector,
 	}, nil
 }
@@ -24,6 +24,18 @@ func DefaultConfig() *Config {
 				DBName:   "test",
 				SSLMode:  "disable",
 			},
+			TxMaxAttempts:    3,
+			TxRetryBaseDelay: repo.Duration(10 * time.Millisecond),
+			TxRetryMaxDelay:  repo.Duration(time.Second),
+
+			MaxOpenConns:        20,
+			MaxIdleConns:        5,
+			ConnMaxLifetime:     repo.Duration(time.Hour),
+			ConnMaxIdleTime:     repo.Duration(10 * time.Minute),
+			StartupWaitTimeout:  repo.Duration(30 * time.Second),
+			HealthCheckInterval: repo.Duration(30 * time.Second),
+
+			SlowQueryThreshold: repo.Duration(200 * time.Millisecond),
 		},
 		HTTP: repo.HTTP{
 			Enable:                false,
@@ -35,7 +47,42 @@ func DefaultConfig() *Config {
 			TLSCertFilePath:       "",
 			TLSKeyFilePath:        "",
 			JWTTokenValidDuration: repo.Duration(30 * time.Minute),
-			JWTTokenHMACKey:       repo.AppName + "_awsd_2024",
+			JWTTokenHMACKey:       "",
+		},
+		Auth: Auth{
+			RefreshTokenValidDuration: repo.Duration(30 * 24 * time.Hour),
+			JWTSigningAlgorithm:       "EdDSA",
+			LoginMaxFailures:          5,
+			LoginIPMaxFailures:        50,
+			LoginLockDuration:         repo.Duration(time.Minute),
+			LoginMaxLockDuration:      repo.Duration(time.Hour),
+			LoginFailureWindow:        repo.Duration(24 * time.Hour),
+			PermissionReloadInterval:  repo.Duration(30 * time.Second),
+			MFATokenValidDuration:     repo.Duration(5 * time.Minute),
+			SecretEncryptionKey:       "",
+		},
+		Mail: Mail{
+			Type:               "file",
+			From:               "no-reply@localhost",
+			SMTPHost:           "",
+			SMTPPort:           587,
+			SMTPUsername:       "",
+			SMTPPassword:       "",
+			CodeValidDuration:  repo.Duration(10 * time.Minute),
+			CodeResendInterval: repo.Duration(time.Minute),
+			ResetPasswordURL:   "http://localhost:8080/reset-password",
+		},
+		Telegram: Telegram{
+			BotToken:          "",
+			AuthValidDuration: repo.Duration(24 * time.Hour),
+		},
+		OIDC: OIDC{
+			StateValidDuration: repo.Duration(10 * time.Minute),
+			Providers:          []OIDCProvider{},
+		},
+		Audit: Audit{
+			RetentionDuration: repo.Duration(180 * 24 * time.Hour),
+			PurgeInterval:     repo.Duration(time.Hour),
 		},
 		Cache: Cache{
 			ExpiredTime: repo.Duration(24 * time.Hour),
@@ -0,0 +1,53 @@
+package entity
+
+import "strconv"
+
+// action of audit_log, format: resource.action
+const (
+	AuditActionLogin                = "login"
+	AuditActionLoginFailed          = "login_failed"
+	AuditActionLoginUnlock          = "login.unlock"
+	AuditActionUserCreate           = "user.create"
+	AuditActionUserDelete           = "user.delete"
+	AuditActionUserRestore          = "user.restore"
+	AuditActionUserRole             = "user.role"
+	AuditActionUserErase            = "user.erase"
+	AuditActionUserPassword         = "user.password"
+	AuditActionUserLogoutAll        = "user.logout_all"
+	AuditActionUserAuthLink         = "user.auth_link"
+	AuditActionUserAuthUnlink       = "user.auth_unlink"
+	AuditActionUserTOTPDisable      = "user.totp_disable"
+	AuditActionRolePermissionGrant  = "role.permission_grant"
+	AuditActionRolePermissionRevoke = "role.permission_revoke"
+	AuditActionAPIKeyCreate         = "api_key.create"
+	AuditActionAPIKeyRevoke         = "api_key.revoke"
+	AuditActionConfigView           = "config.view"
+)
+
+// actor of audit_log without a user
+const (
+	// AuditActorCLI is the ipc cli on the host
+	AuditActorCLI = "cli"
+	// AuditActorSystem is the background tasks
+	AuditActorSystem = "system"
+)
+
+// AuditTargetUser returns the target of the operations on the user
+func AuditTargetUser(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
+
+// AuditTargetIP returns the target of the operations on the client ip
+func AuditTargetIP(ip string) string {
+	return "ip:" + ip
+}
+
+// AuditTargetRole returns the target of the operations on the role
+func AuditTargetRole(role string) string {
+	return "role:" + role
+}
+
+// AuditTargetAPIKey returns the target of the operations on the api key
+func AuditTargetAPIKey(apiKeyID int64) string {
+	return "api_key:" + strconv.FormatInt(apiKeyID, 10)
+}
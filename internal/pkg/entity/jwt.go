@@ -1,9 +1,16 @@
 package entity
 
 import (
-	"github.com/zunkk/go-sidecar/auth/jwt"
+	"github.com/golang-jwt/jwt/v5"
 )
 
+// CustomClaims is the payload of the access token,
+// Subject holds the user id and ID holds the unique token id
 type CustomClaims struct {
-	jwt.BaseClaims
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+	// TokenGeneration must equal to user.token_generation, otherwise the token has been revoked
+	TokenGeneration int64 `json:"gen"`
+	// MFAPending marks the token issued after the first login step, it only proves the first factor
+	MFAPending bool `json:"mfa,omitempty"`
 }
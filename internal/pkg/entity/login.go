@@ -0,0 +1,7 @@
+package entity
+
+// scope of login_attempt
+const (
+	LoginScopeAccount = "account"
+	LoginScopeIP      = "ip"
+)
@@ -0,0 +1,28 @@
+package entity
+
+// permission name format: resource:action
+const (
+	PermissionUserRead    = "user:read"
+	PermissionUserWrite   = "user:write"
+	PermissionRoleRead    = "role:read"
+	PermissionRoleWrite   = "role:write"
+	PermissionAPIKeyRead  = "api_key:read"
+	PermissionAPIKeyWrite = "api_key:write"
+	PermissionAuditRead   = "audit:read"
+)
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// BuiltinPermissions will be synced to the permission table on start
+var BuiltinPermissions = []Permission{
+	{Name: PermissionUserRead, Description: "read user info"},
+	{Name: PermissionUserWrite, Description: "modify user info"},
+	{Name: PermissionRoleRead, Description: "read role permissions"},
+	{Name: PermissionRoleWrite, Description: "grant or revoke role permissions"},
+	{Name: PermissionAPIKeyRead, Description: "read api keys"},
+	{Name: PermissionAPIKeyWrite, Description: "create or revoke api keys"},
+	{Name: PermissionAuditRead, Description: "read audit logs"},
+}
@@ -0,0 +1,26 @@
+package entity
+
+// auth type of user_auth
+const (
+	AuthTypeUsername = "username"
+	AuthTypeTelegram = "tg"
+	AuthTypeEmail    = "email"
+	// AuthTypeTOTP is the second factor, it can not log in by itself
+	AuthTypeTOTP = "totp"
+	// AuthTypeOIDCPrefix is followed by the provider name, e.g. oidc:google
+	AuthTypeOIDCPrefix = "oidc:"
+)
+
+// role of user
+const (
+	UserRoleNormal = "user"
+	UserRoleAdmin  = "admin"
+)
+
+// del_state of all tables
+const (
+	DelStateActive  int64 = 0
+	DelStateDeleted int64 = 1
+	// DelStateErased is a user whose personal data has been erased, it can not be restored
+	DelStateErased int64 = 2
+)
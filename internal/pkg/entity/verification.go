@@ -0,0 +1,9 @@
+package entity
+
+// purpose of verification_code
+const (
+	VerificationPurposeRegister      = "register"
+	VerificationPurposeLogin         = "login"
+	VerificationPurposeResetPassword = "reset_password"
+	VerificationPurposeLink          = "link"
+)
@@ -3,6 +3,16 @@ package errcode
 import "github.com/zunkk/go-sidecar/errcode"
 
 var (
-	ErrRequestParameter = errcode.NewCustomError(10002, "error request parameter")
-	ErrAuthCode         = errcode.NewCustomError(10003, "error auth token")
+	ErrRequestParameter  = errcode.NewCustomError(10002, "error request parameter")
+	ErrAuthCode          = errcode.NewCustomError(10003, "error auth token")
+	ErrAccountExists     = errcode.NewCustomError(10004, "account already exists")
+	ErrAccountOrPassword = errcode.NewCustomError(10005, "incorrect account or password")
+	ErrPermissionDenied  = errcode.NewCustomError(10006, "permission denied")
+	ErrRefreshTokenReuse = errcode.NewCustomError(10007, "refresh token reused")
+	ErrVerificationCode  = errcode.NewCustomError(10008, "invalid or expired verification code")
+	ErrTooFrequent       = errcode.NewCustomError(10009, "request too frequent")
+	ErrLastAuth          = errcode.NewCustomError(10010, "can not remove the last auth method")
+	ErrLoginLocked       = errcode.NewCustomError(10011, "too many failed login attempts")
+	ErrMFACode           = errcode.NewCustomError(10012, "invalid two-factor code")
+	ErrVersionConflict   = errcode.NewCustomError(10013, "modified by another request, reload and retry")
 )
@@ -0,0 +1,25 @@
+package errcode
+
+import "time"
+
+// RetryAfterError tells the caller how long to wait before retrying, the code of the wrapped error is kept
+type RetryAfterError struct {
+	err        error
+	RetryAfter time.Duration
+}
+
+func WithRetryAfter(err error, retryAfter time.Duration) error {
+	return &RetryAfterError{err: err, RetryAfter: retryAfter}
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.err
+}
+
+func (e *RetryAfterError) Cause() error {
+	return e.err
+}
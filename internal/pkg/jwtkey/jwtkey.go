@@ -0,0 +1,341 @@
+package jwtkey
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DirName is the key directory under the repo path
+	DirName = "jwt_keys"
+
+	manifestFileName = "keys.json"
+	rsaKeyBits       = 2048
+)
+
+// Key is an asymmetric signing key, only the active key signs new tokens,
+// retired keys are kept to verify tokens signed before the rotation
+type Key struct {
+	KID        string    `json:"kid"`
+	Algorithm  string    `json:"alg"`
+	CreateTime time.Time `json:"create_time"`
+	RetireTime time.Time `json:"retire_time"`
+
+	privateKey crypto.Signer
+}
+
+func (k *Key) Active() bool {
+	return k.RetireTime.IsZero()
+}
+
+func (k *Key) SigningMethod() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+func (k *Key) PrivateKey() crypto.Signer {
+	return k.privateKey
+}
+
+func (k *Key) PublicKey() crypto.PublicKey {
+	return k.privateKey.Public()
+}
+
+// JWK is the public part of the key in RFC 7517 format
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KID       string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// PublicKey decodes the RSA, EC (P-256) and OKP (Ed25519) keys
+func (j JWK) PublicKey() (crypto.PublicKey, error) {
+	decode := func(name string, value string) ([]byte, error) {
+		raw, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid jwk %s", name)
+		}
+		return raw, nil
+	}
+	switch j.KeyType {
+	case "RSA":
+		n, err := decode("n", j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode("e", j.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if j.Curve != "P-256" {
+			return nil, errors.Errorf("unsupported jwk curve: %s", j.Curve)
+		}
+		x, err := decode("x", j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode("y", j.Y)
+		if err != nil {
+			return nil, err
+		}
+		publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !publicKey.Curve.IsOnCurve(publicKey.X, publicKey.Y) {
+			return nil, errors.New("jwk point is not on the curve")
+		}
+		return publicKey, nil
+	case "OKP":
+		if j.Curve != "Ed25519" {
+			return nil, errors.Errorf("unsupported jwk curve: %s", j.Curve)
+		}
+		x, err := decode("x", j.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 jwk size")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errors.Errorf("unsupported jwk key type: %s", j.KeyType)
+	}
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySet is the keys stored in a directory, described by a manifest file
+type KeySet struct {
+	dir  string
+	keys []*Key
+}
+
+// Load reads the keys in the directory, an active key of the algorithm is generated if there is none
+func Load(dir string, algorithm string, retention time.Duration) (*KeySet, error) {
+	ks := &KeySet{dir: dir}
+	if err := ks.load(); err != nil {
+		return nil, err
+	}
+	if active := ks.Active(); active == nil || active.Algorithm != algorithm {
+		if _, err := ks.Rotate(algorithm, retention); err != nil {
+			return nil, err
+		}
+	}
+	return ks, nil
+}
+
+// Active returns the key used to sign new tokens
+func (ks *KeySet) Active() *Key {
+	for _, k := range ks.keys {
+		if k.Active() {
+			return k
+		}
+	}
+	return nil
+}
+
+// Find returns the key with the kid, nil if not found
+func (ks *KeySet) Find(kid string) *Key {
+	for _, k := range ks.keys {
+		if k.KID == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+func (ks *KeySet) Keys() []*Key {
+	return ks.keys
+}
+
+// Rotate generates a new active key and retires the previous one,
+// keys retired longer than retention ago are removed
+func (ks *KeySet) Rotate(algorithm string, retention time.Duration) (*Key, error) {
+	privateKey, err := generatePrivateKey(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	kidRaw := make([]byte, 8)
+	if _, err := rand.Read(kidRaw); err != nil {
+		return nil, errors.Wrap(err, "failed to generate kid")
+	}
+
+	now := time.Now().UTC()
+	newKey := &Key{
+		KID:        hex.EncodeToString(kidRaw),
+		Algorithm:  algorithm,
+		CreateTime: now,
+		privateKey: privateKey,
+	}
+	if err := os.MkdirAll(ks.dir, 0o700); err != nil {
+		return nil, errors.Wrapf(err, "failed to create key dir %s", ks.dir)
+	}
+	if err := writePrivateKey(ks.keyFilePath(newKey.KID), privateKey); err != nil {
+		return nil, err
+	}
+
+	keys := []*Key{newKey}
+	var removed []*Key
+	for _, k := range ks.keys {
+		if k.Active() {
+			k.RetireTime = now
+		}
+		if now.Sub(k.RetireTime) > retention {
+			removed = append(removed, k)
+			continue
+		}
+		keys = append(keys, k)
+	}
+	if err := writeManifest(filepath.Join(ks.dir, manifestFileName), keys); err != nil {
+		return nil, err
+	}
+	ks.keys = keys
+
+	for _, k := range removed {
+		if err := os.Remove(ks.keyFilePath(k.KID)); err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "failed to remove key file of %s", k.KID)
+		}
+	}
+	return newKey, nil
+}
+
+// JWKS returns the public keys of all usable keys
+func (ks *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(ks.keys))}
+	for _, k := range ks.keys {
+		jwk := JWK{
+			KID:       k.KID,
+			Algorithm: k.Algorithm,
+			Use:       "sig",
+		}
+		switch publicKey := k.PublicKey().(type) {
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func (ks *KeySet) load() error {
+	raw, err := os.ReadFile(filepath.Join(ks.dir, manifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to read key manifest")
+	}
+	var keys []*Key
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return errors.Wrap(err, "failed to decode key manifest")
+	}
+	for _, k := range keys {
+		k.privateKey, err = readPrivateKey(ks.keyFilePath(k.KID))
+		if err != nil {
+			return err
+		}
+	}
+	ks.keys = keys
+	return nil
+}
+
+func (ks *KeySet) keyFilePath(kid string) string {
+	return filepath.Join(ks.dir, kid+".pem")
+}
+
+func generatePrivateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case jwt.SigningMethodEdDSA.Alg():
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate ed25519 key")
+		}
+		return privateKey, nil
+	case jwt.SigningMethodRS256.Alg():
+		privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate rsa key")
+		}
+		return privateKey, nil
+	default:
+		return nil, errors.Errorf("unsupported jwt key algorithm: %s", algorithm)
+	}
+}
+
+func writePrivateKey(path string, privateKey crypto.Signer) error {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal private key")
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write key file %s", path)
+	}
+	return nil
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read key file %s", path)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.Errorf("key file %s is not pem encoded", path)
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse key file %s", path)
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("key file %s is not a signing key", path)
+	}
+	return signer, nil
+}
+
+// writeManifest replaces the manifest atomically so a crash never leaves a half written file
+func writeManifest(path string, keys []*Key) error {
+	raw, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode key manifest")
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, raw, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write key manifest")
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrap(err, "failed to replace key manifest")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package jwtkey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet_Rotate(t *testing.T) {
+	dir := t.TempDir()
+
+	ks, err := Load(dir, jwt.SigningMethodEdDSA.Alg(), time.Hour)
+	require.Nil(t, err)
+	first := ks.Active()
+	require.NotNil(t, first)
+	require.Equal(t, jwt.SigningMethodEdDSA.Alg(), first.Algorithm)
+
+	second, err := ks.Rotate(jwt.SigningMethodRS256.Alg(), time.Hour)
+	require.Nil(t, err)
+	require.Equal(t, second, ks.Active())
+	require.False(t, ks.Find(first.KID).Active())
+
+	reloaded, err := Load(dir, jwt.SigningMethodRS256.Alg(), time.Hour)
+	require.Nil(t, err)
+	require.Equal(t, second.KID, reloaded.Active().KID)
+	require.NotNil(t, reloaded.Find(first.KID))
+
+	jwks := reloaded.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	require.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	require.Equal(t, "OKP", jwks.Keys[1].KeyType)
+	// the jwks round trips to the public keys
+	for _, jwk := range jwks.Keys {
+		publicKey, err := jwk.PublicKey()
+		require.Nil(t, err)
+		require.Equal(t, reloaded.Find(jwk.KID).PublicKey(), publicKey)
+	}
+
+	// keys retired longer than the retention are removed
+	_, err = reloaded.Rotate(jwt.SigningMethodEdDSA.Alg(), 0)
+	require.Nil(t, err)
+	require.Len(t, reloaded.Keys(), 2)
+	require.Nil(t, reloaded.Find(first.KID))
+
+	_, err = reloaded.Rotate("none", time.Hour)
+	require.NotNil(t, err)
+}
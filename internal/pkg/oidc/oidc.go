@@ -0,0 +1,313 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/jwtkey"
+)
+
+const (
+	// clock skew allowed when validating the id token
+	leeway = time.Minute
+	// the jwks is fetched again for an unknown key id at most once per interval
+	jwksRefreshInterval = time.Minute
+)
+
+// Identity is the user authenticated by the provider
+type Identity struct {
+	// Subject is the stable id of the user at the provider
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// AuthProvider is an OAuth2 authorization code flow with PKCE,
+// Provider implements it by OpenID Connect, other OAuth2 providers can implement it by their user info api
+type AuthProvider interface {
+	Name() string
+	// AuthCodeURL returns the provider login page, the provider redirects back with the code and the state
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	// Exchange redeems the code and returns the verified identity
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*Identity, error)
+}
+
+// NewPKCE returns a random code verifier and its S256 code challenge (RFC 7636)
+func NewPKCE() (verifier string, challenge string, err error) {
+	verifier, err = RandomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	return verifier, CodeChallenge(verifier), nil
+}
+
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// RandomString returns size random bytes in base64url
+func RandomString(size int) (string, error) {
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errors.Wrap(err, "failed to generate random string")
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+type ProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// openid is always requested
+	Scopes []string
+}
+
+// Metadata is the part of the discovery document used by the code flow
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce"`
+	AuthorizedParty   string `json:"azp"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// Provider is an OpenID Connect provider, the discovery document and the keys are fetched on first use
+type Provider struct {
+	cfg    ProviderConfig
+	client *http.Client
+
+	lock          sync.Mutex
+	metadata      *Metadata
+	keys          map[string]any
+	keysFetchTime time.Time
+}
+
+func NewProvider(cfg ProviderConfig, client *http.Client) *Provider {
+	return &Provider{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	scopes := []string{"openid"}
+	for _, scope := range p.cfg.Scopes {
+		if scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	authURL, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid authorization endpoint")
+	}
+	for key, values := range authURL.Query() {
+		query[key] = values
+	}
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*Identity, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		// client_secret_basic, see RFC 6749 section 2.3.1
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	var tokenRes struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := p.doJSON(req, &tokenRes); err != nil {
+		if tokenRes.Error != "" {
+			return nil, errors.Errorf("token request failed: %s %s", tokenRes.Error, tokenRes.ErrorDescription)
+		}
+		return nil, err
+	}
+	if tokenRes.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+	return p.verifyIDToken(ctx, tokenRes.IDToken, nonce)
+}
+
+func (p *Provider) verifyIDToken(ctx context.Context, idToken string, nonce string) (*Identity, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(idToken, &claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid id token")
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("invalid id token: nonce mismatch")
+	}
+	// see OpenID Connect Core 1.0 section 3.1.3.7
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.cfg.ClientID {
+		return nil, errors.New("invalid id token: azp mismatch")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("invalid id token: subject is empty")
+	}
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+	return &Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          name,
+	}, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*Metadata, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.cfg.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build discovery request")
+	}
+	var metadata Metadata
+	if err := p.doJSON(req, &metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to discover provider")
+	}
+	if metadata.Issuer != p.cfg.Issuer {
+		return nil, errors.Errorf("issuer mismatch: %s", metadata.Issuer)
+	}
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+// key returns the verification key of the kid, the jwks is fetched again if the provider rotated its keys
+func (p *Provider) key(ctx context.Context, kid string) (any, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(p.keysFetchTime) < jwksRefreshInterval {
+		return nil, errors.Errorf("unknown key id: %s", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadata.JWKSURI, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build jwks request")
+	}
+	var jwks jwtkey.JWKSet
+	if err := p.doJSON(req, &jwks); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch jwks")
+	}
+	keys := make(map[string]any, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// keys of unsupported types are skipped
+		if publicKey, err := jwk.PublicKey(); err == nil {
+			keys[jwk.KID] = publicKey
+		}
+	}
+	p.keys = keys
+	p.keysFetchTime = time.Now()
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, errors.Errorf("unknown key id: %s", kid)
+	}
+	return key, nil
+}
+
+// doJSON decodes the response body into res, an error is returned with the body decoded for non 2xx status
+func (p *Provider) doJSON(req *http.Request, res any) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "request failed")
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	decodeErr := json.Unmarshal(body, res)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return errors.Wrap(decodeErr, "failed to decode response")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/oidc"
+	"github.com/zunkk/go-project-startup/internal/pkg/oidc/oidctest"
+)
+
+func TestProvider(t *testing.T) {
+	server, err := oidctest.NewServer("client", "secret")
+	require.Nil(t, err)
+	defer server.Close()
+
+	newProvider := func(clientSecret string) *oidc.Provider {
+		return oidc.NewProvider(oidc.ProviderConfig{
+			Name:         "fake",
+			Issuer:       server.URL,
+			ClientID:     "client",
+			ClientSecret: clientSecret,
+			RedirectURL:  "http://localhost/callback",
+			Scopes:       []string{"email"},
+		}, http.DefaultClient)
+	}
+	provider := newProvider("secret")
+	ctx := context.Background()
+	user := oidctest.User{Subject: "10001", Email: "alice@example.com", Name: "Alice"}
+
+	authorize := func(provider *oidc.Provider, nonce string) (code string, verifier string) {
+		verifier, challenge, err := oidc.NewPKCE()
+		require.Nil(t, err)
+		authURL, err := provider.AuthCodeURL(ctx, "state-1", nonce, challenge)
+		require.Nil(t, err)
+		u, err := url.Parse(authURL)
+		require.Nil(t, err)
+		require.Equal(t, "openid email", u.Query().Get("scope"))
+
+		code, state, err := server.Authorize(authURL, user)
+		require.Nil(t, err)
+		require.Equal(t, "state-1", state)
+		return code, verifier
+	}
+
+	code, verifier := authorize(provider, "nonce-1")
+	identity, err := provider.Exchange(ctx, code, verifier, "nonce-1")
+	require.Nil(t, err)
+	require.Equal(t, &oidc.Identity{Subject: "10001", Email: "alice@example.com", EmailVerified: true, Name: "Alice"}, identity)
+	// codes are one-time
+	_, err = provider.Exchange(ctx, code, verifier, "nonce-1")
+	require.NotNil(t, err)
+
+	code, _ = authorize(provider, "nonce-2")
+	_, err = provider.Exchange(ctx, code, "wrong-verifier", "nonce-2")
+	require.NotNil(t, err)
+
+	code, verifier = authorize(provider, "nonce-3")
+	_, err = provider.Exchange(ctx, code, verifier, "nonce-other")
+	require.ErrorContains(t, err, "nonce mismatch")
+
+	wrongSecret := newProvider("wrong")
+	code, verifier = authorize(wrongSecret, "nonce-4")
+	_, err = wrongSecret.Exchange(ctx, code, verifier, "nonce-4")
+	require.ErrorContains(t, err, "invalid_client")
+
+	// the issuer must match the discovery document
+	_, err = oidc.NewProvider(oidc.ProviderConfig{Issuer: server.URL + "/other"}, http.DefaultClient).AuthCodeURL(ctx, "", "", "")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,177 @@
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+
+	"github.com/zunkk/go-project-startup/internal/pkg/jwtkey"
+	"github.com/zunkk/go-project-startup/internal/pkg/oidc"
+)
+
+const keyID = "oidctest"
+
+// User is the account logging in at the fake provider
+type User struct {
+	Subject string
+	Email   string
+	Name    string
+}
+
+type authRequest struct {
+	user          User
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// Server is an in-process OpenID Connect provider for tests,
+// Authorize plays the user approving the login instead of a login page
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+
+	lock  sync.Mutex
+	codes map[string]authRequest
+}
+
+func NewServer(clientID string, clientSecret string) (*Server, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		codes:        map[string]authRequest{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.handleDiscovery)
+	mux.HandleFunc("/token", s.handleToken)
+	mux.HandleFunc("/jwks", s.handleJWKS)
+	s.Server = httptest.NewServer(mux)
+	return s, nil
+}
+
+// Authorize approves the login request of authURL as the user, and returns the code and the state redirected back
+func (s *Server) Authorize(authURL string, user User) (code string, state string, err error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	query := u.Query()
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" {
+		return "", "", errors.New("only the code flow with S256 pkce is supported")
+	}
+	if query.Get("client_id") != s.ClientID {
+		return "", "", errors.New("unknown client")
+	}
+	code, err = oidc.RandomString(16)
+	if err != nil {
+		return "", "", err
+	}
+	s.lock.Lock()
+	s.codes[code] = authRequest{
+		user:          user,
+		clientID:      query.Get("client_id"),
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	s.lock.Unlock()
+	return code, query.Get("state"), nil
+}
+
+func (s *Server) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidc.Metadata{
+		Issuer:                s.URL,
+		AuthorizationEndpoint: s.URL + "/authorize",
+		TokenEndpoint:         s.URL + "/token",
+		JWKSURI:               s.URL + "/jwks",
+	})
+}
+
+func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, jwtkey.JWKSet{Keys: []jwtkey.JWK{{
+		KeyType:   "RSA",
+		KID:       keyID,
+		Algorithm: jwt.SigningMethodRS256.Alg(),
+		Use:       "sig",
+		N:         base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+		E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+	}}})
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	tokenError := func(code string, description string) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": description})
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != s.ClientID || clientSecret != s.ClientSecret {
+		tokenError("invalid_client", "client authentication failed")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError("invalid_request", err.Error())
+		return
+	}
+	s.lock.Lock()
+	req, ok := s.codes[r.PostForm.Get("code")]
+	// codes are one-time
+	delete(s.codes, r.PostForm.Get("code"))
+	s.lock.Unlock()
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("redirect_uri") != req.redirectURI {
+		tokenError("invalid_grant", "unknown code")
+		return
+	}
+	if oidc.CodeChallenge(r.PostForm.Get("code_verifier")) != req.codeChallenge {
+		tokenError("invalid_grant", "pkce verification failed")
+		return
+	}
+
+	now := time.Now()
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            s.URL,
+		"sub":            req.user.Subject,
+		"aud":            req.clientID,
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          req.nonce,
+		"email":          req.user.Email,
+		"email_verified": req.user.Email != "",
+		"name":           req.user.Name,
+	})
+	t.Header["kid"] = keyID
+	idToken, err := t.SignedString(s.key)
+	if err != nil {
+		tokenError("server_error", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access-" + idToken[len(idToken)-8:],
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,94 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// KeyFileName is the generated key file under the repo path
+	KeyFileName = "secret.key"
+	keySize     = 32
+)
+
+// Box encrypts the secrets stored in the database with AES-256-GCM
+type Box struct {
+	aead cipher.AEAD
+}
+
+func New(key []byte) (*Box, error) {
+	if len(key) != keySize {
+		return nil, errors.Errorf("secret key must be %d bytes", keySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gcm")
+	}
+	return &Box{aead: aead}, nil
+}
+
+// LoadKey decodes the hex key, a random key is generated into path on first use if hexKey is empty
+func LoadKey(hexKey string, path string) ([]byte, error) {
+	if hexKey != "" {
+		key, err := hex.DecodeString(hexKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid hex secret key")
+		}
+		return key, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err == nil {
+		return hex.DecodeString(strings.TrimSpace(string(raw)))
+	}
+	if !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to read secret key")
+	}
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "failed to generate secret key")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create secret key dir")
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)), 0o600); err != nil {
+		return nil, errors.Wrap(err, "failed to write secret key")
+	}
+	return key, nil
+}
+
+// Seal returns base64(nonce + ciphertext)
+func (b *Box) Seal(plaintext []byte) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "failed to generate nonce")
+	}
+	return base64.RawURLEncoding.EncodeToString(b.aead.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+func (b *Box) Open(sealed string) ([]byte, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid sealed secret")
+	}
+	if len(raw) < b.aead.NonceSize() {
+		return nil, errors.New("sealed secret is too short")
+	}
+	plaintext, err := b.aead.Open(nil, raw[:b.aead.NonceSize()], raw[b.aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open sealed secret")
+	}
+	return plaintext, nil
+}
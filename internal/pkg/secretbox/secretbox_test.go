@@ -0,0 +1,39 @@
+package secretbox
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBox(t *testing.T) {
+	path := filepath.Join(t.TempDir(), KeyFileName)
+	key, err := LoadKey("", path)
+	require.Nil(t, err)
+	// the generated key is reused
+	reloaded, err := LoadKey("", path)
+	require.Nil(t, err)
+	require.Equal(t, key, reloaded)
+
+	box, err := New(key)
+	require.Nil(t, err)
+	sealed, err := box.Seal([]byte("secret"))
+	require.Nil(t, err)
+	plaintext, err := box.Open(sealed)
+	require.Nil(t, err)
+	require.Equal(t, "secret", string(plaintext))
+
+	_, err = box.Open(sealed[:len(sealed)-2] + "AA")
+	require.NotNil(t, err)
+
+	otherKey, err := LoadKey("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", path)
+	require.Nil(t, err)
+	other, err := New(otherKey)
+	require.Nil(t, err)
+	_, err = other.Open(sealed)
+	require.NotNil(t, err)
+
+	_, err = New([]byte("short"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,122 @@
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const webAppKey = "WebAppData"
+
+// User is the telegram account carried by the login data
+type User struct {
+	ID           int64  `json:"id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Username     string `json:"username"`
+	PhotoURL     string `json:"photo_url"`
+	LanguageCode string `json:"language_code"`
+}
+
+// DisplayName prefers the username, then the full name
+func (u *User) DisplayName() string {
+	if u.Username != "" {
+		return u.Username
+	}
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// VerifyLoginWidget checks the fields sent by the Telegram Login Widget,
+// see https://core.telegram.org/widgets/login#checking-authorization
+func VerifyLoginWidget(botToken string, fields map[string]string, maxAge time.Duration) (*User, error) {
+	secretKey := sha256.Sum256([]byte(botToken))
+	if err := verify(secretKey[:], fields, maxAge); err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseInt(fields["id"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid telegram user id")
+	}
+	return &User{
+		ID:        id,
+		FirstName: fields["first_name"],
+		LastName:  fields["last_name"],
+		Username:  fields["username"],
+		PhotoURL:  fields["photo_url"],
+	}, nil
+}
+
+// VerifyWebAppInitData checks the initData of a Telegram Mini App,
+// see https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+func VerifyWebAppInitData(botToken string, initData string, maxAge time.Duration) (*User, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid init data")
+	}
+	fields := make(map[string]string, len(values))
+	for k := range values {
+		fields[k] = values.Get(k)
+	}
+
+	mac := hmac.New(sha256.New, []byte(webAppKey))
+	mac.Write([]byte(botToken))
+	if err := verify(mac.Sum(nil), fields, maxAge); err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(fields["user"]), &user); err != nil {
+		return nil, errors.Wrap(err, "invalid init data user")
+	}
+	if user.ID == 0 {
+		return nil, errors.New("init data user id is empty")
+	}
+	return &user, nil
+}
+
+// DataCheckString joins the fields except hash as sorted key=value lines
+func DataCheckString(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		if k == "hash" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, k+"="+fields[k])
+	}
+	return strings.Join(lines, "\n")
+}
+
+func verify(secretKey []byte, fields map[string]string, maxAge time.Duration) error {
+	hash, err := hex.DecodeString(fields["hash"])
+	if err != nil || len(hash) == 0 {
+		return errors.New("telegram hash is missing or invalid")
+	}
+	mac := hmac.New(sha256.New, secretKey)
+	mac.Write([]byte(DataCheckString(fields)))
+	if !hmac.Equal(mac.Sum(nil), hash) {
+		return errors.New("telegram hash mismatch")
+	}
+
+	authDate, err := strconv.ParseInt(fields["auth_date"], 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid telegram auth_date")
+	}
+	if maxAge > 0 && time.Since(time.Unix(authDate, 0)) > maxAge {
+		return errors.New("telegram auth data expired")
+	}
+	return nil
+}
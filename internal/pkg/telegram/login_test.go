@@ -0,0 +1,53 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fixtures are signed with testBotToken outside of this package
+const (
+	testBotToken      = "123456:TEST-bot-token"
+	widgetFixtureHash = "b445f7c97b5b4a6d31b35f5c5bfe407d67dec887c8c33072bd90ee26a9b74d92"
+	webAppFixture     = "query_id=AAF&user=%7B%22id%22%3A42%2C%22first_name%22%3A%22Alice%22%2C%22username%22%3A%22alice_tg%22%2C%22language_code%22%3A%22en%22%7D&auth_date=1700000000&hash=788aeb49ab2b0f00f0f414ac6095b7b6fedb06aed2b3a386dd1a13e75559c279"
+)
+
+func widgetFixture() map[string]string {
+	return map[string]string{
+		"id":         "42",
+		"first_name": "Alice",
+		"username":   "alice_tg",
+		"auth_date":  "1700000000",
+		"hash":       widgetFixtureHash,
+	}
+}
+
+func TestVerifyLoginWidget(t *testing.T) {
+	user, err := VerifyLoginWidget(testBotToken, widgetFixture(), 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(42), user.ID)
+	require.Equal(t, "alice_tg", user.DisplayName())
+
+	_, err = VerifyLoginWidget("654321:other-bot-token", widgetFixture(), 0)
+	require.NotNil(t, err)
+
+	tampered := widgetFixture()
+	tampered["id"] = "43"
+	_, err = VerifyLoginWidget(testBotToken, tampered, 0)
+	require.NotNil(t, err)
+
+	_, err = VerifyLoginWidget(testBotToken, widgetFixture(), 24*time.Hour)
+	require.ErrorContains(t, err, "expired")
+}
+
+func TestVerifyWebAppInitData(t *testing.T) {
+	user, err := VerifyWebAppInitData(testBotToken, webAppFixture, 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(42), user.ID)
+	require.Equal(t, "en", user.LanguageCode)
+
+	_, err = VerifyWebAppInitData(testBotToken, webAppFixture+"x", 0)
+	require.NotNil(t, err)
+}